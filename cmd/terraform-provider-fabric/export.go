@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/clients/credmgr"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/clients/orchestrator"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/resources/slice"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/services"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/topology"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/version"
+)
+
+// exportSlice prints fabric_slice configuration, preceded by import blocks,
+// for existing slices, so they can be brought under Terraform management.
+func exportSlice(args []string) int {
+	fs := flag.NewFlagSet("export-slice", flag.ContinueOnError)
+	endpoint := fs.String("endpoint", orchestrator.DefaultEndpoint, "orchestrator endpoint")
+	projectID := fs.String("project-id", os.Getenv("FABRIC_PROJECT_ID"), "project of the slices (default $FABRIC_PROJECT_ID)")
+	all := fs.Bool("all", false, "export every active slice instead of the given IDs")
+	noImport := fs.Bool("no-import", false, "omit the import blocks")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s export-slice [flags] <slice-id>...\n\n", os.Args[0])
+		fmt.Fprintln(fs.Output(), "Prints fabric_slice resources and import blocks for existing slices.")
+		fmt.Fprintln(fs.Output(), "Authenticates with $FABRIC_TOKEN, or $FABRIC_REFRESH_TOKEN.")
+		fmt.Fprintln(fs.Output())
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *all == (fs.NArg() > 0) {
+		fs.Usage()
+		return 2
+	}
+
+	ctx := context.Background()
+	httpCfg := orchestrator.HTTPConfig{Timeout: 5 * time.Minute, UserAgent: "terraform-provider-fabric/" + version.Get()}
+	token, err := cliToken(ctx, httpCfg, *projectID)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "export-slice:", err)
+		return 1
+	}
+	slices := services.NewSlicesService(orchestrator.New(orchestrator.Config{
+		Endpoint:  *endpoint,
+		Token:     token,
+		ProjectID: *projectID,
+		HTTP:      httpCfg,
+	}), nil)
+
+	ids := fs.Args()
+	if *all {
+		found, err := slices.List(ctx, "", services.ActiveStates)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "export-slice:", err)
+			return 1
+		}
+		for _, sl := range found {
+			ids = append(ids, sl.ID)
+		}
+	}
+
+	status, exported := 0, 0
+	names := map[string]int{}
+	for _, id := range ids {
+		sl, err := slices.Get(ctx, id)
+		if err == nil && sl.Model == "" {
+			err = errors.New("slice has no model")
+		}
+		var m *topology.Model
+		if err == nil {
+			m, err = topology.ParseModelFormat(sl.ModelFormat, sl.Model)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "export-slice: %s: %v\n", id, err)
+			status = 1
+			continue
+		}
+
+		// Slice names need not be unique across projects or over time.
+		name := slice.ResourceName(sl.Name)
+		if names[name]++; names[name] > 1 {
+			name = fmt.Sprintf("%s_%d", name, names[name])
+		}
+		if exported++; exported > 1 {
+			fmt.Println()
+		}
+		fmt.Print(slice.ExportHCL(name, sl, slice.TopologyFromModel(m), !*noImport))
+	}
+	return status
+}
+
+// cliToken returns $FABRIC_TOKEN, or an id_token for projectID minted from
+// $FABRIC_REFRESH_TOKEN, as the provider would.
+func cliToken(ctx context.Context, httpCfg orchestrator.HTTPConfig, projectID string) (string, error) {
+	if tok := os.Getenv("FABRIC_TOKEN"); tok != "" {
+		return tok, nil
+	}
+	refresh := os.Getenv("FABRIC_REFRESH_TOKEN")
+	if refresh == "" {
+		return "", errors.New("set FABRIC_TOKEN or FABRIC_REFRESH_TOKEN")
+	}
+	tokens := services.NewTokensService(credmgr.New(credmgr.Config{HTTPClient: httpCfg.Client()}))
+	tok, err := tokens.Refresh(ctx, refresh, projectID, "")
+	if err != nil {
+		return "", fmt.Errorf("token refresh: %w", err)
+	}
+	return tok.IDToken, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 
 	"github.com/csc478-wcu/terraform-provider-fabric/internal/provider"
 	"github.com/csc478-wcu/terraform-provider-fabric/internal/version"
@@ -15,13 +16,25 @@ import (
 
 var buildVersion = "v0.1.0" // overridden by goreleaser
 
+// commands run instead of serving the provider when named as the first
+// argument.
+var commands = map[string]func(args []string) int{
+	"export-slice": exportSlice,
+	"validate":     validate,
+}
+
 func main() {
+	version.Set(buildVersion)
+	if len(os.Args) > 1 {
+		if cmd, ok := commands[os.Args[1]]; ok {
+			os.Exit(cmd(os.Args[2:]))
+		}
+	}
+
 	var debug bool
 	flag.BoolVar(&debug, "debug", false, "enable delve-compatible debug mode")
 	flag.Parse()
 
-	version.Set(buildVersion)
-
 	opts := providerserver.ServeOpts{
 		Address: "registry.terraform.io/csc478-wcu/fabric",
 		Debug:   debug,
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/resources/slice"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/topology"
+	"github.com/google/uuid"
+)
+
+// validate checks a slice topology offline, with the defaults and
+// validation fabric_slice applies, and prints the GraphML request it would
+// submit.
+func validate(args []string) int {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	graphID := fs.String("graph-id", "", "graph ID to put in the GraphML (default a random UUID)")
+	quiet := fs.Bool("quiet", false, "only report errors; don't print the GraphML")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s validate [flags] [file.json]\n\n", os.Args[0])
+		fmt.Fprintln(fs.Output(), "Validates a fabric_slice configuration, or just its topology, given as JSON")
+		fmt.Fprintln(fs.Output(), "(e.g. from jsonencode) in the file or on stdin, and prints the GraphML request.")
+		fmt.Fprintln(fs.Output(), "Needs no credentials. Exits 1 if the topology is invalid.")
+		fmt.Fprintln(fs.Output())
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return 2
+	}
+
+	in, src := io.Reader(os.Stdin), "stdin"
+	if fs.NArg() == 1 && fs.Arg(0) != "-" {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "validate:", err)
+			return 2
+		}
+		defer f.Close()
+		in, src = f, fs.Arg(0)
+	}
+	data, err := io.ReadAll(in)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "validate:", err)
+		return 2
+	}
+
+	p, err := slice.ParsePlanJSON(data)
+	if err == nil {
+		id := *graphID
+		if id == "" {
+			id = uuid.NewString()
+		}
+		var graph topology.GraphML
+		if graph, err = slice.CheckRequest(p, id); err == nil {
+			var xml string
+			if xml, err = topology.Marshal(graph); err == nil && !*quiet {
+				fmt.Println(xml)
+			}
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: invalid topology:\n%s\n", src, err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,26 @@
+provider "fabric" {
+  token    = "<your_fabric_token>"
+  endpoint = "https://orchestrator.fabric-testbed.net"
+  ssh_key  = "<your_ssh_key>"
+}
+
+# Keep a long-running experiment alive: every refresh (plan/apply) renews
+# the lease once less than two days are left, for another week, up to four
+# weeks after the slice was created.
+resource "fabric_slice" "long_running" {
+  name = "long-running-slice"
+
+  auto_renew = {
+    renew_before   = "2d"
+    renew_duration = "7d"
+    max_lease      = "28d"
+  }
+
+  topology {
+    nodes = { node1 = { site = "CLEM" } }
+  }
+}
+
+output "lease_remaining_hours" {
+  value = floor(fabric_slice.long_running.lease_remaining_seconds / 3600)
+}
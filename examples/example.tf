@@ -10,9 +10,8 @@ resource "fabric_slice" "example" {
   ssh_keys       = ["<your_ssh_public_key>"]
 
   topology {
-    nodes = [
-      {
-        name          = "node1"
+    nodes = {
+      node1 = {
         site          = "CLEM"
         type          = "VM"
         image_ref     = "ubuntu-20.04"
@@ -21,6 +20,6 @@ resource "fabric_slice" "example" {
         ram           = 4  # GB
         disk          = 10 # GB
       }
-    ]
+    }
   }
 }
@@ -0,0 +1,41 @@
+provider "fabric" {
+  token    = "<your_fabric_token>"
+  endpoint = "https://orchestrator.fabric-testbed.net"
+  ssh_key  = "<your_ssh_key>"
+}
+
+data "fabric_facility_ports" "star" {
+  site = "STAR"
+}
+
+resource "fabric_slice" "campus" {
+  name = "campus-slice"
+
+  topology {
+    nodes = {
+      node1 = {
+        site = "STAR"
+      }
+    }
+
+    facility_ports = [
+      {
+        name      = "Chameleon-StarLight"
+        site      = "STAR"
+        vlan      = "3300"
+        bandwidth = 10
+      }
+    ]
+
+    links = {
+      to-chameleon = {
+        source = "node1"
+        target = "Chameleon-StarLight"
+      }
+    }
+  }
+}
+
+output "star_facility_ports" {
+  value = data.fabric_facility_ports.star.facility_ports
+}
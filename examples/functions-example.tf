@@ -0,0 +1,46 @@
+terraform {
+  required_version = ">= 1.8.0"
+  required_providers {
+    fabric = {
+      source = "csc478-wcu/fabric"
+    }
+  }
+}
+
+provider "fabric" {
+  token    = "<your_fabric_token>"
+  endpoint = "https://orchestrator.fabric-testbed.net"
+  ssh_key  = "<your_ssh_key>"
+}
+
+locals {
+  topology = {
+    nodes = {
+      node1 = {
+        site          = "CLEM"
+        instance_type = provider::fabric::flavor(4, 16, 100)
+      }
+    }
+  }
+}
+
+resource "fabric_slice" "fn" {
+  name           = "functions-slice"
+  lease_end_time = provider::fabric::lease_in("48h", plantimestamp())
+
+  topology {
+    nodes = local.topology.nodes
+  }
+
+  lifecycle {
+    ignore_changes = [lease_end_time]
+  }
+}
+
+output "request_graphml" {
+  value = provider::fabric::graphml(local.topology)
+}
+
+output "requested_vertices" {
+  value = [for v in provider::fabric::parse_slice_model(fabric_slice.fn.graph_model).vertices : v.name]
+}
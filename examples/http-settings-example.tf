@@ -0,0 +1,9 @@
+provider "fabric" {
+  token    = "<your_fabric_token>"
+  endpoint = "https://orchestrator.fabric-testbed.net"
+
+  request_timeout = "2m"
+  proxy_url       = "http://proxy.example.edu:3128"
+  ca_cert_file    = "/etc/ssl/certs/campus-ca.pem"
+  user_agent      = "campus-ci/1.0"
+}
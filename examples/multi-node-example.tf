@@ -10,9 +10,8 @@ resource "fabric_slice" "multi-node" {
   ssh_keys       = ["<your_ssh_public_key>"]
 
   topology {
-    nodes = [
-      {
-        name          = "node1"
+    nodes = {
+      node1 = {
         site          = "CLEM"
         type          = "VM"
         image_ref     = "ubuntu-20.04"
@@ -20,9 +19,8 @@ resource "fabric_slice" "multi-node" {
         cores         = 2
         ram           = 4  # GB
         disk          = 10 # GB
-      },
-      {
-        name          = "node2"
+      }
+      node2 = {
         site          = "NCSA"
         type          = "VM"
         image_ref     = "ubuntu-20.04"
@@ -31,14 +29,13 @@ resource "fabric_slice" "multi-node" {
         ram           = 4  # GB
         disk          = 10 # GB
       }
-    ]
+    }
 
-    links = [
-      {
-        name   = "link1"
+    links = {
+      link1 = {
         source = "node1"
         target = "node2"
       }
-    ]
+    }
   }
 }
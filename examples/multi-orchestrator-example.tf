@@ -0,0 +1,32 @@
+provider "fabric" {
+  token    = "<your_fabric_token>"
+  endpoint = "https://orchestrator.fabric-testbed.net"
+  ssh_key  = "<your_ssh_key>"
+}
+
+provider "fabric" {
+  alias    = "beta"
+  token    = "<your_beta_fabric_token>"
+  endpoint = "https://beta-7.fabric-testbed.net"
+  ssh_key  = "<your_ssh_key>"
+}
+
+resource "fabric_slice" "prod" {
+  name = "prod-slice"
+
+  topology {
+    nodes = { node1 = { site = "CLEM" } }
+  }
+}
+
+# Each slice records the endpoint it was created through; pointing it at a
+# different provider alias later is rejected instead of touching the wrong
+# orchestrator.
+resource "fabric_slice" "beta" {
+  provider = fabric.beta
+  name     = "beta-slice"
+
+  topology {
+    nodes = { node1 = { site = "RENC" } }
+  }
+}
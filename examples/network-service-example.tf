@@ -0,0 +1,35 @@
+provider "fabric" {
+  token    = "<your_fabric_token>"
+  endpoint = "https://orchestrator.fabric-testbed.net"
+  ssh_key  = "<your_ssh_key>"
+}
+
+resource "fabric_slice" "fabnet" {
+  name = "fabnet-slice"
+
+  topology {
+    nodes = {
+      node1 = { site = "CLEM" }
+      node2 = { site = "CLEM" }
+    }
+
+    network_services = [
+      {
+        name = "net1"
+        type = "FABNetv4"
+        interfaces = [
+          { node = "node1", bandwidth = 10 },
+          { node = "node2", bandwidth = 10, vlan = "100" },
+        ]
+      }
+    ]
+  }
+}
+
+output "net1_subnet" {
+  value = fabric_slice.fabnet.topology.network_services[0].subnet
+}
+
+output "net1_gateway" {
+  value = fabric_slice.fabnet.topology.network_services[0].gateway
+}
@@ -0,0 +1,19 @@
+provider "fabric" {
+  token    = "<your_fabric_token>"
+  endpoint = "https://orchestrator.fabric-testbed.net"
+  ssh_key  = "<your_ssh_key>"
+}
+
+# Don't leak a half-provisioned slice: if any sliver fails, delete the slice
+# and fail the apply with the orchestrator's reasons.
+resource "fabric_slice" "all_or_nothing" {
+  name       = "all-or-nothing-slice"
+  on_failure = "delete"
+
+  topology {
+    nodes = {
+      node1 = { site = "CLEM" }
+      node2 = { site = "UTAH" }
+    }
+  }
+}
@@ -0,0 +1,30 @@
+provider "fabric" {
+  token    = "<your_fabric_token>"
+  endpoint = "https://orchestrator.fabric-testbed.net"
+  ssh_key  = "<your_ssh_key>"
+}
+
+resource "fabric_slice" "p4" {
+  name = "p4-slice"
+
+  topology {
+    nodes = {
+      h1   = { site = "STAR" }
+      h2   = { site = "STAR" }
+      p4sw = { site = "STAR", type = "Switch" }
+    }
+
+    network_services = [
+      {
+        name       = "h1-sw"
+        type       = "L2PTP"
+        interfaces = [{ node = "h1", nic_model = "NIC_ConnectX_6" }, { node = "p4sw" }]
+      },
+      {
+        name       = "h2-sw"
+        type       = "L2PTP"
+        interfaces = [{ node = "h2", nic_model = "NIC_ConnectX_6" }, { node = "p4sw" }]
+      },
+    ]
+  }
+}
@@ -0,0 +1,28 @@
+provider "fabric" {
+  token    = "<your_fabric_token>"
+  endpoint = "https://orchestrator.fabric-testbed.net"
+  ssh_key  = "<your_ssh_key>"
+}
+
+resource "fabric_slice" "mirror" {
+  name = "port-mirror-slice"
+
+  topology {
+    nodes = {
+      collector = { site = "UTAH", cores = 8, ram = 32, disk = 100 }
+    }
+
+    network_services = [
+      {
+        name             = "mirror1"
+        type             = "PortMirror"
+        site             = "UTAH"
+        mirror_port      = "HundredGigE0/0/0/5"
+        mirror_direction = "both"
+        interfaces = [
+          { node = "collector", nic_model = "NIC_ConnectX_6" },
+        ]
+      }
+    ]
+  }
+}
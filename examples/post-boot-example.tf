@@ -0,0 +1,30 @@
+provider "fabric" {
+  token               = "<your_fabric_token>"
+  endpoint            = "https://orchestrator.fabric-testbed.net"
+  ssh_key             = file("~/.ssh/fabric_slice.pub")
+  bastion_username    = "<your_bastion_username>"
+  bastion_private_key = file("~/.ssh/fabric_bastion")
+  slice_private_key   = file("~/.ssh/fabric_slice")
+}
+
+resource "fabric_slice" "configured" {
+  name = "post-boot-slice"
+
+  topology {
+    nodes = {
+      node1 = {
+        site      = "CLEM"
+        image_ref = "default_ubuntu_22"
+        post_boot_script = <<-EOT
+          set -e
+          sudo apt-get update -q
+          sudo apt-get install -y -q iperf3
+        EOT
+      }
+    }
+  }
+}
+
+output "node1_post_boot" {
+  value = fabric_slice.configured.topology.nodes["node1"].post_boot_output
+}
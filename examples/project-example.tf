@@ -0,0 +1,25 @@
+provider "fabric" {
+  refresh_token = "<your_fabric_refresh_token>"
+  endpoint      = "https://orchestrator.fabric-testbed.net"
+  project_id    = "<your_default_project_id>"
+  ssh_key       = "<your_ssh_key>"
+}
+
+# Created in the provider's project.
+resource "fabric_slice" "team_a" {
+  name = "team-a-slice"
+
+  topology {
+    nodes = { node1 = { site = "CLEM" } }
+  }
+}
+
+# Created in another project with a token minted for it.
+resource "fabric_slice" "team_b" {
+  name       = "team-b-slice"
+  project_id = "<other_project_id>"
+
+  topology {
+    nodes = { node1 = { site = "NCSA" } }
+  }
+}
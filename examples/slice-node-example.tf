@@ -0,0 +1,50 @@
+provider "fabric" {
+  token    = "<your_fabric_token>"
+  endpoint = "https://orchestrator.fabric-testbed.net"
+  ssh_key  = "<your_ssh_key>"
+}
+
+# The slice carries a single head node; workers are added to it one
+# resource at a time through the modify API.
+resource "fabric_slice" "cluster" {
+  name = "cluster-slice"
+
+  topology {
+    nodes = {
+      head = { site = "CLEM" }
+    }
+  }
+}
+
+locals {
+  workers = toset(["worker1", "worker2", "worker3"])
+}
+
+resource "fabric_slice_node" "worker" {
+  for_each = local.workers
+
+  slice_id = fabric_slice.cluster.id
+  name     = each.key
+  site     = "CLEM"
+  cores    = 4
+  ram      = 8
+}
+
+resource "fabric_network_service" "lan" {
+  slice_id = fabric_slice.cluster.id
+  name     = "lan"
+  type     = "FABNetv4"
+
+  interfaces = concat(
+    [{ node = "head" }],
+    [for w in fabric_slice_node.worker : { node = w.name }],
+  )
+}
+
+output "worker_ips" {
+  value = { for k, w in fabric_slice_node.worker : k => w.management_ip }
+}
+
+output "lan_subnet" {
+  value = fabric_network_service.lan.subnet
+}
@@ -0,0 +1,29 @@
+provider "fabric" {
+  token            = "<your_fabric_token>"
+  endpoint         = "https://orchestrator.fabric-testbed.net"
+  ssh_key          = "<your_ssh_key>"
+  bastion_username = "<your_bastion_username>"
+}
+
+resource "fabric_slice" "ssh" {
+  name = "ssh-slice"
+
+  topology {
+    nodes = {
+      node1 = {
+        site      = "CLEM"
+        image_ref = "default_ubuntu_22"
+      }
+    }
+  }
+}
+
+# Write a config usable as `ssh -F ssh_config node1`.
+resource "local_file" "ssh_config" {
+  filename = "${path.module}/ssh_config"
+  content  = fabric_slice.ssh.ssh_config
+}
+
+output "node1_ssh" {
+  value = fabric_slice.ssh.topology.nodes["node1"].ssh_command
+}
@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    fabric = {
+      source = "csc478-wcu/fabric"
+    }
+  }
+}
+
+provider "fabric" {
+  token    = "<your_fabric_token>"
+  endpoint = "https://orchestrator.fabric-testbed.net"
+}
+
+# Generated bastion key; the private key is only available from state.
+resource "fabric_ssh_key" "bastion" {
+  key_type    = "bastion"
+  comment     = "terraform-bastion"
+  description = "Bastion key managed by Terraform"
+}
+
+# Register an existing public key as a sliver key.
+resource "fabric_ssh_key" "sliver" {
+  key_type   = "sliver"
+  comment    = "terraform-sliver"
+  public_key = file("~/.ssh/id_ed25519.pub")
+}
+
+output "bastion_key_expires_on" {
+  value = fabric_ssh_key.bastion.expires_on
+}
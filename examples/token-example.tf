@@ -0,0 +1,20 @@
+terraform {
+  required_version = ">= 1.10.0"
+  required_providers {
+    fabric = {
+      source = "csc478-wcu/fabric"
+    }
+  }
+}
+
+# With only a refresh token configured, the provider mints its own id_token.
+provider "fabric" {
+  refresh_token = "<your_fabric_refresh_token>"
+  endpoint      = "https://orchestrator.fabric-testbed.net"
+}
+
+# A project-scoped id_token for other providers or provisioners. It is never
+# persisted to state or plan files.
+ephemeral "fabric_token" "project" {
+  project_id = "<your_project_id>"
+}
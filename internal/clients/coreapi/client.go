@@ -0,0 +1,279 @@
+package coreapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const DefaultEndpoint = "https://core-api.fabric-testbed.net"
+
+// Key types accepted by the Core API.
+const (
+	KeyTypeBastion = "bastion"
+	KeyTypeSliver  = "sliver"
+)
+
+// ErrNotFound is returned by GetSSHKey when the key does not exist or has
+// been deactivated.
+var ErrNotFound = errors.New("not found")
+
+type Config struct {
+	Endpoint   string
+	Token      string
+	HTTPClient *http.Client
+}
+
+type SSHKey struct {
+	UUID        string
+	Comment     string
+	Description string
+	KeyType     string // bastion or sliver
+	PublicKey   string // "<ssh_key_type> <public_key> <comment>"
+	PrivateKey  string // only set when the key was generated by the Core API
+	Fingerprint string
+	CreatedOn   string
+	ExpiresOn   string
+	Active      bool
+}
+
+// CreateSSHKeyRequest either generates a new key pair (PublicKey empty) or
+// registers an existing public key.
+type CreateSSHKeyRequest struct {
+	Comment     string
+	Description string
+	KeyType     string
+	PublicKey   string
+}
+
+type Client interface {
+	CreateSSHKey(ctx context.Context, req CreateSSHKeyRequest) (SSHKey, error)
+	GetSSHKey(ctx context.Context, uuid string) (SSHKey, error)
+	ListSSHKeys(ctx context.Context) ([]SSHKey, error)
+	DeleteSSHKey(ctx context.Context, uuid string) error
+}
+
+type client struct {
+	endpoint string
+	token    string
+	http     *http.Client
+}
+
+func New(cfg Config) Client {
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	hc := cfg.HTTPClient
+	if hc == nil {
+		hc = &http.Client{Timeout: 60 * time.Second}
+	}
+	return &client{endpoint: strings.TrimRight(endpoint, "/"), token: cfg.Token, http: hc}
+}
+
+// apiKey is the Core API's representation of an SSH key.
+type apiKey struct {
+	UUID           string `json:"uuid"`
+	Comment        string `json:"comment"`
+	Description    string `json:"description"`
+	FabricKeyType  string `json:"fabric_key_type"`
+	SSHKeyType     string `json:"ssh_key_type"`
+	PublicKey      string `json:"public_key"`
+	PublicOpenSSH  string `json:"public_openssh"`
+	PrivateOpenSSH string `json:"private_openssh"`
+	Fingerprint    string `json:"fingerprint"`
+	CreatedOn      string `json:"created_on"`
+	ExpiresOn      string `json:"expires_on"`
+	Active         *bool  `json:"active"`
+}
+
+func (k apiKey) toSSHKey() SSHKey {
+	pub := k.PublicOpenSSH
+	if pub == "" && k.PublicKey != "" {
+		pub = strings.TrimSpace(strings.Join([]string{k.SSHKeyType, k.PublicKey, k.Comment}, " "))
+	}
+	return SSHKey{
+		UUID:        k.UUID,
+		Comment:     k.Comment,
+		Description: k.Description,
+		KeyType:     k.FabricKeyType,
+		PublicKey:   pub,
+		PrivateKey:  k.PrivateOpenSSH,
+		Fingerprint: k.Fingerprint,
+		CreatedOn:   k.CreatedOn,
+		ExpiresOn:   k.ExpiresOn,
+		Active:      k.Active == nil || *k.Active,
+	}
+}
+
+func (c *client) CreateSSHKey(ctx context.Context, in CreateSSHKeyRequest) (SSHKey, error) {
+	switch in.KeyType {
+	case KeyTypeBastion, KeyTypeSliver:
+	default:
+		return SSHKey{}, fmt.Errorf("create ssh key: key type must be %q or %q, got %q", KeyTypeBastion, KeyTypeSliver, in.KeyType)
+	}
+
+	body := map[string]any{
+		"comment":     in.Comment,
+		"description": in.Description,
+		"keytype":     in.KeyType,
+	}
+	path := "/sshkeys"
+	if in.PublicKey != "" {
+		path = "/sshkeys/store"
+		body["public_openssh"] = in.PublicKey
+	} else {
+		body["store_pubkey"] = true
+	}
+
+	var keys []apiKey
+	if err := c.do(ctx, http.MethodPost, path, body, &keys); err != nil {
+		return SSHKey{}, fmt.Errorf("create ssh key: %w", err)
+	}
+	if len(keys) == 0 {
+		return SSHKey{}, errors.New("create ssh key: empty response")
+	}
+	created := keys[0].toSSHKey()
+	if created.UUID != "" {
+		return created, nil
+	}
+
+	// Generating a key only returns the key pair; find the stored record by
+	// its public key to learn the UUID and metadata.
+	all, err := c.ListSSHKeys(ctx)
+	if err != nil {
+		return SSHKey{}, fmt.Errorf("create ssh key: %w", err)
+	}
+	for _, k := range all {
+		if sameKey(k.PublicKey, created.PublicKey) {
+			k.PrivateKey = created.PrivateKey
+			return k, nil
+		}
+	}
+	return SSHKey{}, errors.New("create ssh key: key was created but could not be found in the key list")
+}
+
+func (c *client) GetSSHKey(ctx context.Context, uuid string) (SSHKey, error) {
+	var keys []apiKey
+	if err := c.do(ctx, http.MethodGet, "/sshkeys/"+url.PathEscape(uuid), nil, &keys); err != nil {
+		return SSHKey{}, fmt.Errorf("get ssh key %s: %w", uuid, err)
+	}
+	if len(keys) == 0 {
+		return SSHKey{}, fmt.Errorf("get ssh key %s: %w", uuid, ErrNotFound)
+	}
+	k := keys[0].toSSHKey()
+	if !k.Active {
+		return SSHKey{}, fmt.Errorf("get ssh key %s: %w", uuid, ErrNotFound)
+	}
+	return k, nil
+}
+
+func (c *client) ListSSHKeys(ctx context.Context) ([]SSHKey, error) {
+	var keys []apiKey
+	if err := c.do(ctx, http.MethodGet, "/sshkeys", nil, &keys); err != nil {
+		return nil, fmt.Errorf("list ssh keys: %w", err)
+	}
+	out := make([]SSHKey, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, k.toSSHKey())
+	}
+	return out, nil
+}
+
+func (c *client) DeleteSSHKey(ctx context.Context, uuid string) error {
+	if err := c.do(ctx, http.MethodDelete, "/sshkeys/"+url.PathEscape(uuid), nil, nil); err != nil {
+		return fmt.Errorf("delete ssh key %s: %w", uuid, err)
+	}
+	return nil
+}
+
+// do sends a request and decodes the "results" array of the Core API
+// envelope into out (when out is non-nil).
+func (c *client) do(ctx context.Context, method, path string, in any, out *[]apiKey) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	raw, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("status %d: %s", resp.StatusCode, errorMessage(raw))
+	}
+	if out == nil || len(bytes.TrimSpace(raw)) == 0 {
+		return nil
+	}
+
+	// {"results":[...],"size":1,"status":200,"type":"sshkeys"}
+	var env struct {
+		Results []apiKey `json:"results"`
+	}
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	*out = env.Results
+	return nil
+}
+
+// errorMessage extracts the messages of a Core API error envelope,
+//
+//	{"errors":[{"message":"Bad Request","details":"..."}],"status":400,"type":"error"}
+//
+// falling back to the raw body when it is not one.
+func errorMessage(raw []byte) string {
+	var env struct {
+		Errors []struct {
+			Message string `json:"message"`
+			Details string `json:"details"`
+		} `json:"errors"`
+	}
+	if json.Unmarshal(raw, &env) != nil || len(env.Errors) == 0 {
+		return strings.TrimSpace(string(raw))
+	}
+	msgs := make([]string, 0, len(env.Errors))
+	for _, e := range env.Errors {
+		msg := e.Message
+		if e.Details != "" {
+			msg += ": " + e.Details
+		}
+		msgs = append(msgs, msg)
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// sameKey compares the type and key material of two OpenSSH public keys,
+// ignoring the trailing comment.
+func sameKey(a, b string) bool {
+	fa, fb := strings.Fields(a), strings.Fields(b)
+	return len(fa) >= 2 && len(fb) >= 2 && fa[0] == fb[0] && fa[1] == fb[1]
+}
@@ -0,0 +1,186 @@
+package coreapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeCoreAPI serves /sshkeys from memory the way the Core API does:
+// generated keys come back without their UUID, stored keys with it.
+type fakeCoreAPI struct {
+	mu   sync.Mutex
+	keys map[string]apiKey
+	next int
+}
+
+func (f *fakeCoreAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer tok" {
+		writeError(w, http.StatusUnauthorized, "Unauthorized", "Login required")
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := strings.TrimPrefix(r.URL.Path, "/sshkeys/")
+	switch {
+	case r.Method == http.MethodPost && (r.URL.Path == "/sshkeys" || r.URL.Path == "/sshkeys/store"):
+		var in struct {
+			Comment       string `json:"comment"`
+			Description   string `json:"description"`
+			KeyType       string `json:"keytype"`
+			PublicOpenSSH string `json:"public_openssh"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			writeError(w, http.StatusBadRequest, "Bad Request", err.Error())
+			return
+		}
+		if len(in.Description) < 5 {
+			writeError(w, http.StatusBadRequest, "Bad Request", "description must be at least 5 characters")
+			return
+		}
+		f.next++
+		k := apiKey{
+			UUID:          fmt.Sprintf("key-%d", f.next),
+			Comment:       in.Comment,
+			Description:   in.Description,
+			FabricKeyType: in.KeyType,
+			PublicOpenSSH: in.PublicOpenSSH,
+			Fingerprint:   fmt.Sprintf("SHA256:%d", f.next),
+		}
+		reply := k
+		if r.URL.Path == "/sshkeys" {
+			k.PublicOpenSSH = fmt.Sprintf("ssh-ed25519 AAAA%d %s", f.next, in.Comment)
+			reply = apiKey{PublicOpenSSH: k.PublicOpenSSH, PrivateOpenSSH: "PRIVATE"}
+		}
+		f.keys[k.UUID] = k
+		writeResults(w, http.StatusOK, reply)
+	case r.Method == http.MethodGet && r.URL.Path == "/sshkeys":
+		var all []apiKey
+		for _, k := range f.keys {
+			all = append(all, k)
+		}
+		writeResults(w, http.StatusOK, all...)
+	case r.Method == http.MethodGet:
+		k, ok := f.keys[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Not Found", "no key "+id)
+			return
+		}
+		writeResults(w, http.StatusOK, k)
+	case r.Method == http.MethodDelete:
+		if _, ok := f.keys[id]; !ok {
+			writeError(w, http.StatusNotFound, "Not Found", "no key "+id)
+			return
+		}
+		delete(f.keys, id)
+		w.WriteHeader(http.StatusOK)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed", r.Method)
+	}
+}
+
+func writeResults(w http.ResponseWriter, status int, keys ...apiKey) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{"results": keys, "size": len(keys), "status": status, "type": "sshkeys"})
+}
+
+func writeError(w http.ResponseWriter, status int, msg, details string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{
+		"errors": []map[string]string{{"message": msg, "details": details}},
+		"size":   1, "status": status, "type": "error",
+	})
+}
+
+func newTestClient(t *testing.T, token string) Client {
+	t.Helper()
+	srv := httptest.NewServer(&fakeCoreAPI{keys: map[string]apiKey{}})
+	t.Cleanup(srv.Close)
+	return New(Config{Endpoint: srv.URL + "/", Token: token, HTTPClient: srv.Client()})
+}
+
+func TestSSHKeyLifecycle(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t, "tok")
+
+	stored, err := c.CreateSSHKey(ctx, CreateSSHKeyRequest{
+		Comment: "mine", Description: "stored key", KeyType: KeyTypeSliver, PublicKey: "ssh-ed25519 AAAAx mine",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.UUID == "" || stored.KeyType != KeyTypeSliver || stored.PrivateKey != "" || !stored.Active {
+		t.Errorf("stored key = %+v", stored)
+	}
+
+	generated, err := c.CreateSSHKey(ctx, CreateSSHKeyRequest{Comment: "gen", Description: "generated key", KeyType: KeyTypeBastion})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if generated.UUID == "" || generated.UUID == stored.UUID || generated.PrivateKey != "PRIVATE" || generated.Fingerprint == "" {
+		t.Errorf("generated key = %+v", generated)
+	}
+
+	got, err := c.GetSSHKey(ctx, stored.UUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.PublicKey != "ssh-ed25519 AAAAx mine" || got.Description != "stored key" {
+		t.Errorf("read key = %+v", got)
+	}
+
+	if err := c.DeleteSSHKey(ctx, stored.UUID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetSSHKey(ctx, stored.UUID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("get after delete: err = %v, want ErrNotFound", err)
+	}
+	if err := c.DeleteSSHKey(ctx, stored.UUID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second delete: err = %v, want ErrNotFound", err)
+	}
+
+	all, err := c.ListSSHKeys(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 1 || all[0].UUID != generated.UUID {
+		t.Errorf("keys left = %+v, want only %s", all, generated.UUID)
+	}
+}
+
+func TestErrorDecoding(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := newTestClient(t, "tok").CreateSSHKey(ctx, CreateSSHKeyRequest{Description: "x", KeyType: KeyTypeSliver})
+	if want := "create ssh key: status 400: Bad Request: description must be at least 5 characters"; err == nil || err.Error() != want {
+		t.Errorf("create error = %v, want %q", err, want)
+	}
+
+	_, err = newTestClient(t, "wrong").ListSSHKeys(ctx)
+	if want := "list ssh keys: status 401: Unauthorized: Login required"; err == nil || err.Error() != want {
+		t.Errorf("list error = %v, want %q", err, want)
+	}
+
+	if _, err := newTestClient(t, "tok").CreateSSHKey(ctx, CreateSSHKeyRequest{KeyType: "admin"}); err == nil || !strings.Contains(err.Error(), `got "admin"`) {
+		t.Errorf("bad key type error = %v", err)
+	}
+
+	for _, tc := range []struct{ raw, want string }{
+		{`{"errors":[{"message":"A"},{"message":"B","details":"why"}]}`, "A; B: why"},
+		{"upstream timeout\n", "upstream timeout"},
+		{`{"results":[]}`, `{"results":[]}`},
+	} {
+		if got := errorMessage([]byte(tc.raw)); got != tc.want {
+			t.Errorf("errorMessage(%q) = %q, want %q", tc.raw, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package credmgr
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const DefaultEndpoint = "https://cm.fabric-testbed.net"
+
+type Config struct {
+	Endpoint   string
+	HTTPClient *http.Client
+}
+
+// Token is a freshly minted id_token and the refresh token returned with it.
+type Token struct {
+	IDToken      string
+	RefreshToken string
+	CreatedAt    string
+}
+
+// ExpiresAt reads the exp claim of the id_token. The token is not verified;
+// this is only used to tell users when to expect it to stop working.
+func (t Token) ExpiresAt() (time.Time, bool) {
+	parts := strings.Split(t.IDToken, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if json.Unmarshal(payload, &claims) != nil || claims.Exp == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(claims.Exp, 0).UTC(), true
+}
+
+type Client interface {
+	Refresh(ctx context.Context, refreshToken, projectID, scope string) (Token, error)
+}
+
+type client struct {
+	endpoint string
+	http     *http.Client
+}
+
+func New(cfg Config) Client {
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	hc := cfg.HTTPClient
+	if hc == nil {
+		hc = &http.Client{Timeout: 60 * time.Second}
+	}
+	return &client{endpoint: strings.TrimRight(endpoint, "/"), http: hc}
+}
+
+func (c *client) Refresh(ctx context.Context, refreshToken, projectID, scope string) (Token, error) {
+	if refreshToken == "" {
+		return Token{}, errors.New("refresh token: no refresh token given")
+	}
+
+	q := url.Values{}
+	if projectID != "" {
+		q.Set("project_id", projectID)
+	}
+	if scope != "" {
+		q.Set("scope", scope)
+	}
+	body, _ := json.Marshal(map[string]string{"refresh_token": refreshToken})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		c.endpoint+"/credmgr/tokens/refresh?"+q.Encode(), bytes.NewReader(body))
+	if err != nil {
+		return Token{}, fmt.Errorf("refresh token: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return Token{}, fmt.Errorf("refresh token: %w", err)
+	}
+	defer resp.Body.Close()
+	raw, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return Token{}, fmt.Errorf("refresh token: status %d: %s", resp.StatusCode, string(raw))
+	}
+
+	// {"data":[{"id_token":"...","refresh_token":"...","created_at":"..."}],"size":1,"status":200,"type":"token"}
+	var out struct {
+		Data []struct {
+			IDToken      string `json:"id_token"`
+			RefreshToken string `json:"refresh_token"`
+			CreatedAt    string `json:"created_at"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &out); err != nil || len(out.Data) == 0 || out.Data[0].IDToken == "" {
+		return Token{}, errors.New("refresh token: response did not contain an id_token")
+	}
+	d := out.Data[0]
+	return Token{IDToken: d.IDToken, RefreshToken: d.RefreshToken, CreatedAt: d.CreatedAt}, nil
+}
@@ -6,31 +6,67 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 
 	openapi "github.com/csc478-wcu/fabric-orchestrator-go-client"
 )
 
-const defaultGraphFormat = "GRAPHML" // allowed: GRAPHML, JSON_NODELINK, CYTOSCAPE, NONE
+const DefaultEndpoint = "https://orchestrator.fabric-testbed.net"
+
+const defaultGraphFormat = "GRAPHML" // allowed: GRAPHML, JSON_NODELINK, CYTOSCAPE
 
 type NotFoundError struct{ msg string }
 
 func (e NotFoundError) Error() string { return e.msg }
 
+// Slice is the subset of an orchestrator slice record the provider uses.
+// Model holds the slice graph encoded as ModelFormat.
+type Slice struct {
+	ID             string
+	Name           string
+	State          string
+	LeaseStartTime string
+	LeaseEndTime   string
+	Model          string
+	ModelFormat    string
+	ProjectID      string
+}
+
+// Sliver is the subset of an orchestrator sliver record the provider uses.
+// Notice carries the orchestrator's explanation when provisioning failed.
+type Sliver struct {
+	ID     string
+	Name   string
+	Type   string
+	State  string
+	Notice string
+}
+
 type Config struct {
-	Endpoint string
-	Token    string
+	Endpoint    string
+	Token       string
+	GraphFormat string // defaults to defaultGraphFormat
+	ProjectID   string // when set, ListSlices only returns this project's slices
+	HTTP        HTTPConfig
 }
 
 type Client interface {
 	CreateSlice(ctx context.Context, name, leaseEnd, model string, sshKeys []string) (sliceID, state string, slivers int, err error)
-	GetSlice(ctx context.Context, sliceID string) (name, state string, err error)
+	GetSlice(ctx context.Context, sliceID string) (Slice, error)
 	DeleteSlice(ctx context.Context, sliceID string) error
+	ModifySlice(ctx context.Context, sliceID, model string) error
+	AcceptModify(ctx context.Context, sliceID string) error
+	RenewSlice(ctx context.Context, sliceID, leaseEnd string) error
+	ListSlices(ctx context.Context, name string, states []string) ([]Slice, error)
+	ListSlivers(ctx context.Context, sliceID string) ([]Sliver, error)
 	ListResources(ctx context.Context, level *int32, includes, excludes []string) ([]string, error)
 }
 
 type client struct {
-	api   *openapi.APIClient
-	token string
+	api         *openapi.APIClient
+	token       string
+	graphFormat string
+	projectID   string
 }
 
 func New(cfg Config) Client {
@@ -39,16 +75,26 @@ func New(cfg Config) Client {
 		conf.Servers = openapi.ServerConfigurations{{URL: cfg.Endpoint}}
 	}
 	// ensure our content-type fix transport is used
-	conf.HTTPClient = withContentTypeFix(conf.HTTPClient)
+	conf.HTTPClient = withContentTypeFix(conf.HTTPClient, cfg.HTTP)
+	if cfg.HTTP.UserAgent != "" {
+		conf.UserAgent = cfg.HTTP.UserAgent
+	}
+
+	graphFormat := cfg.GraphFormat
+	if graphFormat == "" {
+		graphFormat = defaultGraphFormat
+	}
 
 	return &client{
-		api:   openapi.NewAPIClient(conf),
-		token: cfg.Token,
+		api:         openapi.NewAPIClient(conf),
+		token:       cfg.Token,
+		graphFormat: graphFormat,
+		projectID:   cfg.ProjectID,
 	}
 }
 
 func (c *client) CreateSlice(ctx context.Context, name, leaseEnd, model string, sshKeys []string) (string, string, int, error) {
-	apiCtx := context.WithValue(ctx, openapi.ContextAccessToken, c.token)
+	apiCtx := context.WithValue(c.logCtx(ctx), openapi.ContextAccessToken, c.token)
 
 	body := openapi.SlicesPost{}
 	body.SetGraphModel(model)
@@ -114,22 +160,31 @@ func (c *client) CreateSlice(ctx context.Context, name, leaseEnd, model string,
 	return "", "", 0, fmt.Errorf("create slice: %w", err)
 }
 
-func (c *client) GetSlice(ctx context.Context, sliceID string) (string, string, error) {
-	apiCtx := context.WithValue(ctx, openapi.ContextAccessToken, c.token)
+func (c *client) GetSlice(ctx context.Context, sliceID string) (Slice, error) {
+	apiCtx := context.WithValue(c.logCtx(ctx), openapi.ContextAccessToken, c.token)
 
 	res, httpResp, err := c.api.SlicesAPI.
 		SlicesSliceIdGet(apiCtx, sliceID).
-		GraphFormat(defaultGraphFormat). // "GRAPHML"
+		GraphFormat(c.graphFormat).
 		Execute()
 
 	// Happy path: SDK decoded a known type
 	if err == nil {
 		data := res.GetData()
 		if len(data) == 0 {
-			return "", "", NotFoundError{msg: fmt.Sprintf("slice %s not found (empty data)", sliceID)}
+			return Slice{}, NotFoundError{msg: fmt.Sprintf("slice %s not found (empty data)", sliceID)}
 		}
 		s := data[0]
-		return s.GetName(), s.GetState(), nil
+		return Slice{
+			ID:             s.GetSliceId(),
+			Name:           s.GetName(),
+			State:          s.GetState(),
+			LeaseStartTime: s.GetLeaseStartTime(),
+			LeaseEndTime:   s.GetLeaseEndTime(),
+			Model:          s.GetModel(),
+			ModelFormat:    c.graphFormat,
+			ProjectID:      s.GetProjectId(),
+		}, nil
 	}
 
 	// Fallback path: SDK errored but we have an HTTP response body
@@ -139,34 +194,49 @@ func (c *client) GetSlice(ctx context.Context, sliceID string) (string, string,
 
 		// Not found?
 		if httpResp.StatusCode == 404 {
-			return "", "", NotFoundError{msg: fmt.Sprintf("slice %s not found: %s", sliceID, string(raw))}
+			return Slice{}, NotFoundError{msg: fmt.Sprintf("slice %s not found: %s", sliceID, string(raw))}
 		}
 
 		// Some deployments return a 200 with a different shape the SDK can't decode.
 		if httpResp.StatusCode == 200 {
 			var fb struct {
 				Data []struct {
-					Name  string `json:"name"`
-					State string `json:"state"`
+					SliceID        string `json:"slice_id"`
+					Name           string `json:"name"`
+					State          string `json:"state"`
+					LeaseStartTime string `json:"lease_start_time"`
+					LeaseEndTime   string `json:"lease_end_time"`
+					Model          string `json:"model"`
+					ProjectID      string `json:"project_id"`
 				} `json:"data"`
 			}
 			if json.Unmarshal(raw, &fb) == nil && len(fb.Data) > 0 {
-				return fb.Data[0].Name, fb.Data[0].State, nil
+				d := fb.Data[0]
+				return Slice{
+					ID:             d.SliceID,
+					Name:           d.Name,
+					State:          d.State,
+					LeaseStartTime: d.LeaseStartTime,
+					LeaseEndTime:   d.LeaseEndTime,
+					Model:          d.Model,
+					ModelFormat:    c.graphFormat,
+					ProjectID:      d.ProjectID,
+				}, nil
 			}
 			// If the shape changes again, surface the raw so we can tweak quickly.
-			return "", "", fmt.Errorf("get slice: unrecognized 200 response shape: %s", string(raw))
+			return Slice{}, fmt.Errorf("get slice: unrecognized 200 response shape: %s", string(raw))
 		}
 
 		// Other status codes: return original error plus raw for debugging
-		return "", "", fmt.Errorf("get slice: %w raw=%s", err, string(raw))
+		return Slice{}, fmt.Errorf("get slice: %w raw=%s", err, string(raw))
 	}
 
 	// No httpResp available: return the SDK error
-	return "", "", fmt.Errorf("get slice: %w", err)
+	return Slice{}, fmt.Errorf("get slice: %w", err)
 }
 
 func (c *client) DeleteSlice(ctx context.Context, sliceID string) error {
-	apiCtx := context.WithValue(ctx, openapi.ContextAccessToken, c.token)
+	apiCtx := context.WithValue(c.logCtx(ctx), openapi.ContextAccessToken, c.token)
 
 	_, httpResp, err := c.api.SlicesAPI.
 		SlicesDeleteSliceIdDelete(apiCtx, sliceID).
@@ -198,8 +268,169 @@ func (c *client) DeleteSlice(ctx context.Context, sliceID string) error {
 	return fmt.Errorf("delete slice: %w", err)
 }
 
+// ModifySlice submits model as the slice's new topology. The change takes
+// effect once accepted with AcceptModify.
+func (c *client) ModifySlice(ctx context.Context, sliceID, model string) error {
+	apiCtx := context.WithValue(c.logCtx(ctx), openapi.ContextAccessToken, c.token)
+
+	_, httpResp, err := c.api.SlicesAPI.
+		SlicesModifySliceIdPut(apiCtx, sliceID).
+		Body(model).
+		Execute()
+	if err == nil {
+		return nil
+	}
+	return untypedResult("modify slice", sliceID, httpResp, err)
+}
+
+func (c *client) AcceptModify(ctx context.Context, sliceID string) error {
+	apiCtx := context.WithValue(c.logCtx(ctx), openapi.ContextAccessToken, c.token)
+
+	_, httpResp, err := c.api.SlicesAPI.
+		SlicesModifySliceIdAcceptPost(apiCtx, sliceID).
+		Execute()
+	if err == nil {
+		return nil
+	}
+	return untypedResult("accept modify", sliceID, httpResp, err)
+}
+
+// RenewSlice extends the slice's lease to leaseEnd (FABRIC lease format).
+func (c *client) RenewSlice(ctx context.Context, sliceID, leaseEnd string) error {
+	apiCtx := context.WithValue(c.logCtx(ctx), openapi.ContextAccessToken, c.token)
+
+	_, httpResp, err := c.api.SlicesAPI.
+		SlicesRenewSliceIdPost(apiCtx, sliceID).
+		LeaseEndTime(leaseEnd).
+		Execute()
+	if err == nil {
+		return nil
+	}
+	return untypedResult("renew slice", sliceID, httpResp, err)
+}
+
+// untypedResult maps an SDK error on a call whose response body the
+// provider doesn't need: untyped 2xx responses are success, 404 is a
+// NotFoundError, anything else is returned with the raw body.
+func untypedResult(op, sliceID string, httpResp *http.Response, err error) error {
+	if httpResp == nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	raw, _ := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+
+	switch httpResp.StatusCode {
+	case 200, 202, 204:
+		return nil
+	case 404:
+		return NotFoundError{msg: fmt.Sprintf("slice %s not found: %s", sliceID, string(raw))}
+	default:
+		return fmt.Errorf("%s: %w raw=%s", op, err, string(raw))
+	}
+}
+
+// ListSlices returns the caller's slices, optionally filtered by exact name
+// and state. Models are not included.
+func (c *client) ListSlices(ctx context.Context, name string, states []string) ([]Slice, error) {
+	apiCtx := context.WithValue(c.logCtx(ctx), openapi.ContextAccessToken, c.token)
+
+	call := c.api.SlicesAPI.SlicesGet(apiCtx).AsSelf(true).Limit(1000)
+	if name != "" {
+		call = call.Name(name).ExactMatch(true)
+	}
+	if len(states) > 0 {
+		call = call.States(states)
+	}
+	res, httpResp, err := call.Execute()
+
+	var out []Slice
+	switch {
+	case err == nil:
+		for _, s := range res.GetData() {
+			out = append(out, Slice{
+				ID:             s.GetSliceId(),
+				Name:           s.GetName(),
+				State:          s.GetState(),
+				LeaseStartTime: s.GetLeaseStartTime(),
+				LeaseEndTime:   s.GetLeaseEndTime(),
+				ProjectID:      s.GetProjectId(),
+			})
+		}
+	case httpResp != nil:
+		raw, _ := io.ReadAll(httpResp.Body)
+		_ = httpResp.Body.Close()
+
+		// No matching slices is reported as a 404 by some deployments.
+		if httpResp.StatusCode == 404 {
+			return nil, nil
+		}
+		var fb struct {
+			Data []struct {
+				SliceID        string `json:"slice_id"`
+				Name           string `json:"name"`
+				State          string `json:"state"`
+				LeaseStartTime string `json:"lease_start_time"`
+				LeaseEndTime   string `json:"lease_end_time"`
+				ProjectID      string `json:"project_id"`
+			} `json:"data"`
+		}
+		if httpResp.StatusCode != 200 || json.Unmarshal(raw, &fb) != nil {
+			return nil, fmt.Errorf("list slices: %w raw=%s", err, string(raw))
+		}
+		for _, d := range fb.Data {
+			out = append(out, Slice{
+				ID:             d.SliceID,
+				Name:           d.Name,
+				State:          d.State,
+				LeaseStartTime: d.LeaseStartTime,
+				LeaseEndTime:   d.LeaseEndTime,
+				ProjectID:      d.ProjectID,
+			})
+		}
+	default:
+		return nil, fmt.Errorf("list slices: %w", err)
+	}
+
+	if c.projectID == "" {
+		return out, nil
+	}
+	filtered := out[:0]
+	for _, s := range out {
+		if s.ProjectID == "" || s.ProjectID == c.projectID {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered, nil
+}
+
+// ListSlivers returns the slivers of the slice.
+func (c *client) ListSlivers(ctx context.Context, sliceID string) ([]Sliver, error) {
+	apiCtx := context.WithValue(c.logCtx(ctx), openapi.ContextAccessToken, c.token)
+
+	res, httpResp, err := c.api.SliversAPI.SliversGet(apiCtx).SliceId(sliceID).AsSelf(true).Execute()
+	if err != nil {
+		if err := untypedResult("list slivers", sliceID, httpResp, err); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	var out []Sliver
+	for _, sv := range res.GetData() {
+		name, _ := sv.GetSliver()["Name"].(string)
+		out = append(out, Sliver{
+			ID:     sv.GetSliverId(),
+			Name:   name,
+			Type:   sv.GetSliverType(),
+			State:  sv.GetState(),
+			Notice: sv.GetNotice(),
+		})
+	}
+	return out, nil
+}
+
 func (c *client) ListResources(ctx context.Context, level *int32, includes, excludes []string) ([]string, error) {
-	apiCtx := context.WithValue(ctx, openapi.ContextAccessToken, c.token)
+	apiCtx := context.WithValue(c.logCtx(ctx), openapi.ContextAccessToken, c.token)
 	call := c.api.ResourcesAPI.ResourcesGet(apiCtx)
 	if level != nil {
 		call = call.Level(*level)
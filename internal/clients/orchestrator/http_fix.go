@@ -2,11 +2,60 @@ package orchestrator
 
 import (
 	"bytes"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"io"
 	"net/http"
+	"net/url"
+	"time"
 )
 
+// HTTPConfig controls how FABRIC services are reached.
+type HTTPConfig struct {
+	Timeout            time.Duration  // per request; 0 means none
+	ProxyURL           *url.URL       // nil uses HTTP(S)_PROXY from the environment
+	RootCAs            *x509.CertPool // nil uses the system roots
+	InsecureSkipVerify bool
+	UserAgent          string
+}
+
+// BaseTransport returns a transport with the proxy, TLS and User-Agent
+// settings applied. It is shared by every client the provider builds.
+func (h HTTPConfig) BaseTransport() http.RoundTripper {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if h.ProxyURL != nil {
+		t.Proxy = http.ProxyURL(h.ProxyURL)
+	}
+	if h.RootCAs != nil || h.InsecureSkipVerify {
+		t.TLSClientConfig = &tls.Config{
+			RootCAs:            h.RootCAs,
+			InsecureSkipVerify: h.InsecureSkipVerify, //nolint:gosec // opt-in for test deployments
+			MinVersion:         tls.VersionTLS12,
+		}
+	}
+	if h.UserAgent == "" {
+		return t
+	}
+	return userAgentTransport{rt: t, ua: h.UserAgent}
+}
+
+// Client returns an *http.Client using BaseTransport and the timeout.
+func (h HTTPConfig) Client() *http.Client {
+	return &http.Client{Timeout: h.Timeout, Transport: h.BaseTransport()}
+}
+
+type userAgentTransport struct {
+	rt http.RoundTripper
+	ua string
+}
+
+func (t userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.ua)
+	return t.rt.RoundTrip(req)
+}
+
 type ctFixTransport struct{ rt http.RoundTripper }
 
 func (t ctFixTransport) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -28,14 +77,13 @@ func (t ctFixTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	return resp, nil
 }
 
-func withContentTypeFix(c *http.Client) *http.Client {
+// withContentTypeFix builds the orchestrator transport chain:
+// content-type fix -> logging -> User-Agent -> proxy/TLS base transport.
+func withContentTypeFix(c *http.Client, h HTTPConfig) *http.Client {
 	if c == nil {
 		c = &http.Client{}
 	}
-	rt := c.Transport
-	if rt == nil {
-		rt = http.DefaultTransport
-	}
-	c.Transport = ctFixTransport{rt: rt}
+	c.Timeout = h.Timeout
+	c.Transport = ctFixTransport{rt: loggingTransport{rt: h.BaseTransport()}}
 	return c
 }
@@ -0,0 +1,84 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// LogSubsystem is the tflog subsystem for orchestrator HTTP traffic
+// (TF_LOG_PROVIDER_FABRIC_ORCHESTRATOR=trace).
+const LogSubsystem = "orchestrator"
+
+// Secrets that may show up in request/response bodies or headers.
+var maskRegexes = []*regexp.Regexp{
+	regexp.MustCompile(`Bearer\s+\S+`),
+	regexp.MustCompile(`(ssh-(rsa|dss|ed25519)|ecdsa-sha2-nistp\d+|sk-[a-z0-9@.-]+)\s+[A-Za-z0-9+/=]+`),
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`),
+}
+
+// logCtx returns ctx with the orchestrator subsystem set up and the
+// client's token and any SSH keys masked.
+func (c *client) logCtx(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, LogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_FABRIC", LogSubsystem))
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, LogSubsystem, "authorization")
+	ctx = tflog.SubsystemMaskLogRegexes(ctx, LogSubsystem, maskRegexes...)
+	if c.token != "" {
+		ctx = tflog.SubsystemMaskLogStrings(ctx, LogSubsystem, c.token)
+	}
+	return ctx
+}
+
+// loggingTransport logs each request and response: method, path, status,
+// duration and request ID at DEBUG, bodies at TRACE.
+type loggingTransport struct{ rt http.RoundTripper }
+
+func (t loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	fields := map[string]interface{}{
+		"method": req.Method,
+		"path":   req.URL.Path,
+	}
+	if req.URL.RawQuery != "" {
+		fields["query"] = req.URL.RawQuery
+	}
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Sending orchestrator request", fields)
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			b, _ := io.ReadAll(body)
+			_ = body.Close()
+			tflog.SubsystemTrace(ctx, LogSubsystem, "Orchestrator request body", map[string]interface{}{"body": string(b)})
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.rt.RoundTrip(req)
+	fields["duration_ms"] = time.Since(start).Milliseconds()
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemDebug(ctx, LogSubsystem, "Orchestrator request failed", fields)
+		return resp, err
+	}
+
+	fields["status"] = resp.StatusCode
+	if id := resp.Header.Get("X-Request-Id"); id != "" {
+		fields["request_id"] = id
+	}
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Received orchestrator response", fields)
+
+	if resp.Body != nil {
+		b, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(b))
+		if err != nil {
+			return resp, err
+		}
+		tflog.SubsystemTrace(ctx, LogSubsystem, "Orchestrator response body", map[string]interface{}{"body": string(b)})
+	}
+	return resp, nil
+}
@@ -0,0 +1,266 @@
+package sshexec
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+type Config struct {
+	// BastionHost is the jump host ("host" or "host:port"). When empty,
+	// nodes are dialed directly.
+	BastionHost     string
+	BastionUsername string
+	BastionKey      string // PEM private key for the bastion
+	NodeKey         string // PEM private key for the slice nodes
+
+	// KnownHostsFile is an OpenSSH known_hosts file. When set, the
+	// bastion's host key must be listed in it, and a node's host key must
+	// match if the node is listed. When empty, host keys are not checked.
+	KnownHostsFile string
+
+	// DialTimeout bounds connecting to and completing the SSH handshake
+	// with each hop.
+	DialTimeout time.Duration
+}
+
+// ConnectError means a host could not be reached: the TCP connection, or
+// the bastion's forwarded connection, failed. Nodes that are still booting
+// fail this way, so it is worth retrying; authentication and host key
+// failures are not ConnectErrors.
+type ConnectError struct {
+	Addr string
+	Err  error
+}
+
+func (e *ConnectError) Error() string { return fmt.Sprintf("connect to %s: %v", e.Addr, e.Err) }
+
+func (e *ConnectError) Unwrap() error { return e.Err }
+
+// Result is the outcome of a script that ran to completion.
+type Result struct {
+	ExitCode int
+	Output   string // combined stdout and stderr
+}
+
+type Client interface {
+	// RunScript feeds script to a shell on host as user. Errors mean the
+	// script could not be run (connection, authentication); a script that
+	// ran and failed is reported through Result.ExitCode.
+	RunScript(ctx context.Context, host, user, script string) (Result, error)
+}
+
+type client struct {
+	cfg         Config
+	bastionAuth ssh.AuthMethod
+	nodeAuth    ssh.AuthMethod
+
+	bastionHostKey ssh.HostKeyCallback
+	nodeHostKey    ssh.HostKeyCallback
+}
+
+func New(cfg Config) (Client, error) {
+	if cfg.DialTimeout == 0 {
+		cfg.DialTimeout = 30 * time.Second
+	}
+	c := &client{
+		cfg: cfg,
+		// Without known hosts there is nothing to check against: slice VMs
+		// get fresh host keys on every create.
+		bastionHostKey: ssh.InsecureIgnoreHostKey(), //nolint:gosec // opt-in through KnownHostsFile
+		nodeHostKey:    ssh.InsecureIgnoreHostKey(), //nolint:gosec // opt-in through KnownHostsFile
+	}
+	if cfg.KnownHostsFile != "" {
+		known, err := knownhosts.New(cfg.KnownHostsFile)
+		if err != nil {
+			return nil, fmt.Errorf("known hosts: %w", err)
+		}
+		c.bastionHostKey, c.nodeHostKey = known, unlistedAllowed(known)
+	}
+
+	if cfg.NodeKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(cfg.NodeKey))
+		if err != nil {
+			return nil, fmt.Errorf("slice private key: %w", err)
+		}
+		c.nodeAuth = ssh.PublicKeys(signer)
+	}
+	if cfg.BastionKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(cfg.BastionKey))
+		if err != nil {
+			return nil, fmt.Errorf("bastion private key: %w", err)
+		}
+		c.bastionAuth = ssh.PublicKeys(signer)
+	}
+	return c, nil
+}
+
+func (c *client) RunScript(ctx context.Context, host, user, script string) (Result, error) {
+	if c.nodeAuth == nil {
+		return Result{}, errors.New("no slice private key configured")
+	}
+
+	conn, err := c.dial(ctx, host, user)
+	if err != nil {
+		return Result{}, err
+	}
+	defer conn.Close()
+
+	sess, err := conn.NewSession()
+	if err != nil {
+		return Result{}, fmt.Errorf("open session on %s: %w", host, err)
+	}
+	defer sess.Close()
+
+	var out syncBuffer
+	sess.Stdout = &out
+	sess.Stderr = &out
+	sess.Stdin = strings.NewReader(script)
+
+	done := make(chan error, 1)
+	go func() { done <- sess.Run("bash -s") }()
+
+	select {
+	case <-ctx.Done():
+		_ = sess.Signal(ssh.SIGKILL)
+		return Result{}, ctx.Err()
+	case err = <-done:
+	}
+
+	var exitErr *ssh.ExitError
+	switch {
+	case err == nil:
+		return Result{ExitCode: 0, Output: out.String()}, nil
+	case errors.As(err, &exitErr):
+		return Result{ExitCode: exitErr.ExitStatus(), Output: out.String()}, nil
+	default:
+		return Result{}, fmt.Errorf("run script on %s: %w", host, err)
+	}
+}
+
+// unlistedAllowed accepts host keys of hosts known does not list, and
+// checks the rest: slice VMs get fresh keys on every create, so most are
+// not listed.
+func unlistedAllowed(known ssh.HostKeyCallback) ssh.HostKeyCallback {
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		err := known(hostname, remote, key)
+		var keyErr *knownhosts.KeyError
+		if errors.As(err, &keyErr) && len(keyErr.Want) == 0 {
+			return nil
+		}
+		return err
+	}
+}
+
+// dial connects to host:22 as user, jumping through the bastion if one is
+// configured.
+func (c *client) dial(ctx context.Context, host, user string) (*ssh.Client, error) {
+	nodeCfg := &ssh.ClientConfig{
+		User:            user,
+		Auth:            []ssh.AuthMethod{c.nodeAuth},
+		HostKeyCallback: c.nodeHostKey,
+		Timeout:         c.cfg.DialTimeout,
+	}
+	nodeAddr := withPort(host)
+
+	if c.cfg.BastionHost == "" {
+		return dialContext(ctx, nil, nodeAddr, nodeCfg, c.cfg.DialTimeout)
+	}
+
+	if c.bastionAuth == nil {
+		return nil, errors.New("no bastion private key configured")
+	}
+	bastionCfg := &ssh.ClientConfig{
+		User:            c.cfg.BastionUsername,
+		Auth:            []ssh.AuthMethod{c.bastionAuth},
+		HostKeyCallback: c.bastionHostKey,
+		Timeout:         c.cfg.DialTimeout,
+	}
+	bastion, err := dialContext(ctx, nil, withPort(c.cfg.BastionHost), bastionCfg, c.cfg.DialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := dialContext(ctx, bastion, nodeAddr, nodeCfg, c.cfg.DialTimeout)
+	if err != nil {
+		bastion.Close()
+		return nil, err
+	}
+	go func() {
+		_ = conn.Wait()
+		bastion.Close()
+	}()
+	return conn, nil
+}
+
+// dialContext opens an SSH client connection to addr, either directly or
+// tunnelled through via. Connecting and the handshake each get timeout.
+func dialContext(ctx context.Context, via *ssh.Client, addr string, cfg *ssh.ClientConfig, timeout time.Duration) (*ssh.Client, error) {
+	dialCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	var (
+		nc  net.Conn
+		err error
+	)
+	if via == nil {
+		var d net.Dialer
+		nc, err = d.DialContext(dialCtx, "tcp", addr)
+	} else {
+		nc, err = via.DialContext(dialCtx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, &ConnectError{Addr: addr, Err: err}
+	}
+
+	// ssh.NewClientConn has no deadline of its own, and connections
+	// tunnelled through the bastion don't support SetDeadline, so a stalled
+	// handshake is ended by closing the connection.
+	hsCtx, hsCancel := context.WithTimeout(ctx, timeout)
+	defer hsCancel()
+	stop := context.AfterFunc(hsCtx, func() { nc.Close() })
+	sc, chans, reqs, err := ssh.NewClientConn(nc, addr, cfg)
+	if !stop() {
+		if err == nil {
+			sc.Close()
+		}
+		return nil, fmt.Errorf("ssh handshake with %s: %w", addr, hsCtx.Err())
+	}
+	if err != nil {
+		nc.Close()
+		return nil, fmt.Errorf("ssh handshake with %s: %w", addr, err)
+	}
+	return ssh.NewClient(sc, chans, reqs), nil
+}
+
+// syncBuffer is a bytes.Buffer safe for the session's stdout and stderr
+// copiers to write to at once.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func withPort(host string) string {
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return host
+	}
+	return net.JoinHostPort(host, "22")
+}
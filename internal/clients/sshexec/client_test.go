@@ -0,0 +1,299 @@
+package sshexec
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+func newKey(t *testing.T) (ssh.PublicKey, string) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sshPub, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sshPub, string(pem.EncodeToMemory(block))
+}
+
+func TestRunScriptConnectionRefused(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+
+	_, key := newKey(t)
+	c, err := New(Config{NodeKey: key, DialTimeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.RunScript(context.Background(), addr, "rocky", "true")
+	var connErr *ConnectError
+	if !errors.As(err, &connErr) {
+		t.Fatalf("error = %v, want a ConnectError", err)
+	}
+}
+
+func TestRunScriptHandshakeTimeout(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		// Accept and never answer, like a wedged sshd.
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	_, key := newKey(t)
+	c, err := New(Config{NodeKey: key, DialTimeout: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	_, err = c.RunScript(context.Background(), l.Addr().String(), "rocky", "true")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want the handshake to time out", err)
+	}
+	var connErr *ConnectError
+	if errors.As(err, &connErr) {
+		t.Errorf("handshake timeout reported as ConnectError: %v", err)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("RunScript took %s with a 100ms timeout", d)
+	}
+}
+
+func TestKnownHosts(t *testing.T) {
+	listed, _ := newKey(t)
+	other, _ := newKey(t)
+	file := filepath.Join(t.TempDir(), "known_hosts")
+	line := knownhosts.Line([]string{"bastion.example.net", "node1.example.net"}, listed) + "\n"
+	if err := os.WriteFile(file, []byte(line), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, key := newKey(t)
+	cl, err := New(Config{NodeKey: key, KnownHostsFile: file})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := cl.(*client)
+	addr := &net.TCPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 22}
+
+	for _, tc := range []struct {
+		name     string
+		check    ssh.HostKeyCallback
+		host     string
+		key      ssh.PublicKey
+		accepted bool
+	}{
+		{"bastion listed", c.bastionHostKey, "bastion.example.net:22", listed, true},
+		{"bastion mismatch", c.bastionHostKey, "bastion.example.net:22", other, false},
+		{"bastion unlisted", c.bastionHostKey, "other.example.net:22", listed, false},
+		{"node listed", c.nodeHostKey, "node1.example.net:22", listed, true},
+		{"node mismatch", c.nodeHostKey, "node1.example.net:22", other, false},
+		{"node unlisted", c.nodeHostKey, "node2.example.net:22", other, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.check(tc.host, addr, tc.key)
+			if accepted := err == nil; accepted != tc.accepted {
+				t.Errorf("accepted = %v (err %v), want %v", accepted, err, tc.accepted)
+			}
+		})
+	}
+
+	if _, err := New(Config{NodeKey: key, KnownHostsFile: filepath.Join(t.TempDir(), "missing")}); err == nil {
+		t.Error("New accepted a missing known_hosts file")
+	}
+}
+
+// sshStandIn is a local SSH server standing in for the bastion and the
+// nodes. "bash -s" sessions echo the script back and exit with
+// exitStatus; direct-tcpip channels are forwarded, so the server can also
+// serve as a bastion jumping to itself.
+type sshStandIn struct {
+	addr       string
+	hostKey    ssh.PublicKey
+	exitStatus uint32
+}
+
+func startSSHStandIn(t *testing.T, clientKey ssh.PublicKey, exitStatus uint32) *sshStandIn {
+	t.Helper()
+	hostPub, hostPEM := newKey(t)
+	hostSigner, err := ssh.ParsePrivateKey([]byte(hostPEM))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) != string(clientKey.Marshal()) {
+				return nil, errors.New("unknown key")
+			}
+			return nil, nil
+		},
+	}
+	cfg.AddHostKey(hostSigner)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	s := &sshStandIn{addr: l.Addr().String(), hostKey: hostPub, exitStatus: exitStatus}
+	go func() {
+		for {
+			nc, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(nc, cfg)
+		}
+	}()
+	return s
+}
+
+func (s *sshStandIn) serve(nc net.Conn, cfg *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(nc, cfg)
+	if err != nil {
+		nc.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for nch := range chans {
+		switch nch.ChannelType() {
+		case "session":
+			go s.session(nch)
+		case "direct-tcpip":
+			go forward(nch)
+		default:
+			_ = nch.Reject(ssh.UnknownChannelType, "unsupported")
+		}
+	}
+}
+
+func (s *sshStandIn) session(nch ssh.NewChannel) {
+	ch, reqs, err := nch.Accept()
+	if err != nil {
+		return
+	}
+	defer ch.Close()
+	for req := range reqs {
+		if req.Type != "exec" {
+			_ = req.Reply(false, nil)
+			continue
+		}
+		_ = req.Reply(true, nil)
+		script, _ := io.ReadAll(ch)
+		fmt.Fprintf(ch, "ran: %s", script)
+		fmt.Fprint(ch.Stderr(), "warning\n")
+		_, _ = ch.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{s.exitStatus}))
+		return
+	}
+}
+
+func forward(nch ssh.NewChannel) {
+	var target struct {
+		Host     string
+		Port     uint32
+		OrigHost string
+		OrigPort uint32
+	}
+	if err := ssh.Unmarshal(nch.ExtraData(), &target); err != nil {
+		_ = nch.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+	conn, err := net.Dial("tcp", net.JoinHostPort(target.Host, strconv.Itoa(int(target.Port))))
+	if err != nil {
+		_ = nch.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+	ch, reqs, err := nch.Accept()
+	if err != nil {
+		conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	go func() {
+		_, _ = io.Copy(ch, conn)
+		ch.Close()
+	}()
+	_, _ = io.Copy(conn, ch)
+	conn.Close()
+}
+
+func TestRunScriptStandIn(t *testing.T) {
+	clientPub, clientKey := newKey(t)
+	node := startSSHStandIn(t, clientPub, 3)
+	bastion := startSSHStandIn(t, clientPub, 0)
+
+	for _, tc := range []struct {
+		name string
+		cfg  Config
+	}{
+		{"direct", Config{NodeKey: clientKey}},
+		{"via bastion", Config{NodeKey: clientKey, BastionHost: bastion.addr, BastionUsername: "me", BastionKey: clientKey}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := New(tc.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res, err := c.RunScript(context.Background(), node.addr, "rocky", "echo hi\n")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.ExitCode != 3 || !strings.Contains(res.Output, "ran: echo hi") || !strings.Contains(res.Output, "warning") {
+				t.Errorf("result = %+v", res)
+			}
+		})
+	}
+
+	// A bastion whose host key is not the one listed is refused, and not
+	// as a retryable connection failure.
+	other, _ := newKey(t)
+	file := filepath.Join(t.TempDir(), "known_hosts")
+	host, port, _ := net.SplitHostPort(bastion.addr)
+	line := knownhosts.Line([]string{knownhosts.Normalize(net.JoinHostPort(host, port))}, other) + "\n"
+	if err := os.WriteFile(file, []byte(line), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	c, err := New(Config{NodeKey: clientKey, BastionHost: bastion.addr, BastionUsername: "me", BastionKey: clientKey, KnownHostsFile: file})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.RunScript(context.Background(), node.addr, "rocky", "true")
+	var connErr *ConnectError
+	if err == nil || errors.As(err, &connErr) {
+		t.Fatalf("error = %v, want a host key failure", err)
+	}
+}
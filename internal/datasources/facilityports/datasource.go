@@ -0,0 +1,71 @@
+package facilityports
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/runtime"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+var _ datasource.DataSource = &DataSource{}
+
+type DataSource struct{ deps *runtime.Deps }
+
+func New() datasource.DataSource { return &DataSource{} }
+
+func (d *DataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_facility_ports"
+}
+
+func (d *DataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = Schema()
+}
+
+func (d *DataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	deps, ok := req.ProviderData.(*runtime.Deps)
+	if !ok {
+		resp.Diagnostics.AddError("Internal error", fmt.Sprintf("unexpected provider deps type %T", req.ProviderData))
+		return
+	}
+	d.deps = deps
+}
+
+func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var p Plan
+	resp.Diagnostics.Append(req.Config.Get(ctx, &p)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ports, err := d.deps.Resources.FacilityPorts(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Fetch facility ports failed", err.Error())
+		return
+	}
+
+	items := make([]FacilityPort, 0, len(ports))
+	for _, fp := range ports {
+		if p.Site != nil && *p.Site != fp.Site {
+			continue
+		}
+		ranges := fp.VLANRanges
+		if ranges == nil {
+			ranges = []string{}
+		}
+		items = append(items, FacilityPort{
+			Name:       fp.Name,
+			Site:       fp.Site,
+			Interface:  fp.Interface,
+			LocalName:  fp.LocalName,
+			VLANRanges: ranges,
+		})
+	}
+
+	p.ID = "fabric-facility-ports"
+	p.FacilityPorts = items
+	resp.Diagnostics.Append(resp.State.Set(ctx, &p)...)
+}
@@ -0,0 +1,15 @@
+package facilityports
+
+type Plan struct {
+	ID            string         `tfsdk:"id"`
+	Site          *string        `tfsdk:"site"`
+	FacilityPorts []FacilityPort `tfsdk:"facility_ports"`
+}
+
+type FacilityPort struct {
+	Name       string   `tfsdk:"name"`
+	Site       string   `tfsdk:"site"`
+	Interface  string   `tfsdk:"interface"`
+	LocalName  string   `tfsdk:"local_name"`
+	VLANRanges []string `tfsdk:"vlan_ranges"`
+}
@@ -0,0 +1,33 @@
+package facilityports
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func Schema() schema.Schema {
+	return schema.Schema{
+		MarkdownDescription: "Lists facility ports and their VLAN ranges from the FABRIC resource advertisement.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true},
+			"site": schema.StringAttribute{
+				MarkdownDescription: "Only return facility ports at this site.",
+				Optional:            true,
+			},
+			"facility_ports": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name":       schema.StringAttribute{Computed: true},
+						"site":       schema.StringAttribute{Computed: true},
+						"interface":  schema.StringAttribute{Computed: true},
+						"local_name": schema.StringAttribute{Computed: true},
+						"vlan_ranges": schema.ListAttribute{
+							ElementType: types.StringType, Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,74 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/runtime"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ ephemeral.EphemeralResource = &EphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &EphemeralResource{}
+
+type EphemeralResource struct{ deps *runtime.Deps }
+
+func New() ephemeral.EphemeralResource { return &EphemeralResource{} }
+
+func (e *EphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_token"
+}
+
+func (e *EphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = Schema()
+}
+
+func (e *EphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	deps, ok := req.ProviderData.(*runtime.Deps)
+	if !ok {
+		resp.Diagnostics.AddError("Internal error", fmt.Sprintf("unexpected provider deps type %T", req.ProviderData))
+		return
+	}
+	e.deps = deps
+}
+
+func (e *EphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var m Model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &m)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	refresh := e.deps.RefreshToken
+	if !m.RefreshToken.IsNull() && m.RefreshToken.ValueString() != "" {
+		refresh = m.RefreshToken.ValueString()
+	}
+	if refresh == "" {
+		resp.Diagnostics.AddError("Missing refresh token",
+			"Set 'refresh_token' on the ephemeral resource or the provider (or FABRIC_REFRESH_TOKEN).")
+		return
+	}
+
+	project := e.deps.ProjectID
+	if !m.ProjectID.IsNull() && m.ProjectID.ValueString() != "" {
+		project = m.ProjectID.ValueString()
+	}
+
+	tok, err := e.deps.Tokens.Refresh(ctx, refresh, project, m.Scope.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Token refresh failed", err.Error())
+		return
+	}
+
+	m.IDToken = types.StringValue(tok.IDToken)
+	m.ExpiresAt = types.StringNull()
+	if exp, ok := tok.ExpiresAt(); ok {
+		m.ExpiresAt = types.StringValue(exp.Format(time.RFC3339))
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &m)...)
+}
@@ -0,0 +1,11 @@
+package token
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type Model struct {
+	RefreshToken types.String `tfsdk:"refresh_token"`
+	ProjectID    types.String `tfsdk:"project_id"`
+	Scope        types.String `tfsdk:"scope"`
+	IDToken      types.String `tfsdk:"id_token"`
+	ExpiresAt    types.String `tfsdk:"expires_at"`
+}
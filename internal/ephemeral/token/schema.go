@@ -0,0 +1,33 @@
+package token
+
+import "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+
+func Schema() schema.Schema {
+	return schema.Schema{
+		MarkdownDescription: "Exchanges a FABRIC refresh token for a short-lived id_token. The token is never written to state or plan files.",
+		Attributes: map[string]schema.Attribute{
+			"refresh_token": schema.StringAttribute{
+				MarkdownDescription: "Refresh token to exchange. Defaults to the provider's `refresh_token`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Project the token is scoped to. Defaults to the provider's `project_id`, then the token's default project.",
+				Optional:            true,
+			},
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "Token scope. Defaults to `all`.",
+				Optional:            true,
+			},
+			"id_token": schema.StringAttribute{
+				MarkdownDescription: "The minted id_token.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "Expiry of the id_token (RFC3339), if it could be determined.",
+				Computed:            true,
+			},
+		},
+	}
+}
@@ -0,0 +1,89 @@
+package functions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// dynamicToJSON converts an arbitrary Terraform value into JSON so it can be
+// decoded into the provider's domain structs.
+func dynamicToJSON(ctx context.Context, v types.Dynamic) ([]byte, error) {
+	if v.IsNull() || v.IsUnderlyingValueNull() {
+		return nil, fmt.Errorf("value must not be null")
+	}
+	tfv, err := v.UnderlyingValue().ToTerraformValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	goVal, err := toGo(tfv)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(goVal)
+}
+
+func toGo(v tftypes.Value) (any, error) {
+	if v.IsNull() {
+		return nil, nil
+	}
+	if !v.IsKnown() {
+		return nil, fmt.Errorf("value is not known yet")
+	}
+
+	typ := v.Type()
+	switch {
+	case typ.Is(tftypes.String):
+		var s string
+		err := v.As(&s)
+		return s, err
+	case typ.Is(tftypes.Bool):
+		var b bool
+		err := v.As(&b)
+		return b, err
+	case typ.Is(tftypes.Number):
+		var f big.Float
+		if err := v.As(&f); err != nil {
+			return nil, err
+		}
+		if i, acc := f.Int64(); acc == big.Exact {
+			return i, nil
+		}
+		f64, _ := f.Float64()
+		return f64, nil
+	case typ.Is(tftypes.Object{}), typ.Is(tftypes.Map{}):
+		var m map[string]tftypes.Value
+		if err := v.As(&m); err != nil {
+			return nil, err
+		}
+		out := make(map[string]any, len(m))
+		for k, ev := range m {
+			gv, err := toGo(ev)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			out[k] = gv
+		}
+		return out, nil
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		var l []tftypes.Value
+		if err := v.As(&l); err != nil {
+			return nil, err
+		}
+		out := make([]any, 0, len(l))
+		for i, ev := range l {
+			gv, err := toGo(ev)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			out = append(out, gv)
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %s", typ)
+	}
+}
@@ -0,0 +1,49 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &Flavor{}
+
+type Flavor struct{}
+
+func NewFlavor() function.Function { return &Flavor{} }
+
+func (f *Flavor) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "flavor"
+}
+
+func (f *Flavor) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Builds a FABRIC instance type name.",
+		MarkdownDescription: "Returns the `instance_type` for the given sizing, e.g. `flavor(4, 16, 100)` is `fabric.c4.m16.d100`.",
+		Parameters: []function.Parameter{
+			function.Int64Parameter{Name: "cores", MarkdownDescription: "Number of cores."},
+			function.Int64Parameter{Name: "ram", MarkdownDescription: "RAM in GB."},
+			function.Int64Parameter{Name: "disk", MarkdownDescription: "Disk in GB."},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *Flavor) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var cores, ram, disk int64
+	resp.Error = req.Arguments.Get(ctx, &cores, &ram, &disk)
+	if resp.Error != nil {
+		return
+	}
+	for i, v := range []int64{cores, ram, disk} {
+		if v <= 0 {
+			resp.Error = function.ConcatFuncErrors(resp.Error,
+				function.NewArgumentFuncError(int64(i), "value must be greater than zero"))
+		}
+	}
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, fmt.Sprintf("fabric.c%d.m%d.d%d", cores, ram, disk))
+}
@@ -0,0 +1,128 @@
+package functions
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/topology"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// run calls f with args and returns its result, or its error.
+func run(t *testing.T, f function.Function, result attr.Value, args ...attr.Value) (attr.Value, *function.FuncError) {
+	t.Helper()
+	resp := function.RunResponse{Result: function.NewResultData(result)}
+	f.Run(context.Background(), function.RunRequest{Arguments: function.NewArgumentsData(args)}, &resp)
+	return resp.Result.Value(), resp.Error
+}
+
+func wantArgError(t *testing.T, err *function.FuncError, arg int64, text string) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("no error, want one for argument %d", arg)
+	}
+	if err.FunctionArgument == nil || *err.FunctionArgument != arg {
+		t.Errorf("error %q is not for argument %d", err.Text, arg)
+	}
+	if !strings.Contains(err.Text, text) {
+		t.Errorf("error %q does not mention %q", err.Text, text)
+	}
+}
+
+func TestFlavor(t *testing.T) {
+	got, err := run(t, NewFlavor(), types.StringUnknown(), types.Int64Value(4), types.Int64Value(16), types.Int64Value(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := types.StringValue("fabric.c4.m16.d100"); !got.Equal(want) {
+		t.Errorf("flavor(4, 16, 100) = %v, want %v", got, want)
+	}
+
+	for _, tc := range []struct {
+		cores, ram, disk int64
+		arg              int64
+	}{
+		{0, 16, 100, 0},
+		{4, -8, 100, 1},
+		{4, 16, 0, 2},
+		{-1, 0, 0, 0}, // the first bad argument is reported
+	} {
+		_, err := run(t, NewFlavor(), types.StringUnknown(),
+			types.Int64Value(tc.cores), types.Int64Value(tc.ram), types.Int64Value(tc.disk))
+		wantArgError(t, err, tc.arg, "greater than zero")
+	}
+}
+
+func TestLeaseIn(t *testing.T) {
+	for _, tc := range []struct{ duration, from, want string }{
+		{"7d", "2025-01-01T00:00:00Z", "2025-01-08 00:00:00 +0000"},
+		{"36h", "2025-01-01T00:00:00Z", "2025-01-02 12:00:00 +0000"},
+		{"90m", "2025-01-01T10:00:00+02:00", "2025-01-01 11:30:00 +0200"},
+		// plantimestamp() carries fractional seconds; the lease drops them.
+		{"24h", "2025-01-01T00:00:00.123456789Z", "2025-01-02 00:00:00 +0000"},
+	} {
+		got, err := run(t, NewLeaseIn(), types.StringUnknown(), types.StringValue(tc.duration), types.StringValue(tc.from))
+		if err != nil {
+			t.Errorf("lease_in(%q, %q): %v", tc.duration, tc.from, err)
+			continue
+		}
+		if want := types.StringValue(tc.want); !got.Equal(want) {
+			t.Errorf("lease_in(%q, %q) = %v, want %v", tc.duration, tc.from, got, want)
+		}
+	}
+
+	_, err := run(t, NewLeaseIn(), types.StringUnknown(), types.StringValue("0d"), types.StringValue("2025-01-01T00:00:00Z"))
+	wantArgError(t, err, 0, "invalid lease duration")
+	_, err = run(t, NewLeaseIn(), types.StringUnknown(), types.StringValue("7d"), types.StringValue("2025-01-01 00:00:00"))
+	wantArgError(t, err, 1, "RFC3339")
+}
+
+func TestGraphMLMapKeyedNodes(t *testing.T) {
+	node := func(site string) attr.Value {
+		return types.ObjectValueMust(map[string]attr.Type{"site": types.StringType},
+			map[string]attr.Value{"site": types.StringValue(site)})
+	}
+	nodeType := types.ObjectType{AttrTypes: map[string]attr.Type{"site": types.StringType}}
+	topo := types.ObjectValueMust(
+		map[string]attr.Type{"nodes": types.MapType{ElemType: nodeType}},
+		map[string]attr.Value{"nodes": types.MapValueMust(nodeType, map[string]attr.Value{
+			"n1": node("RENC"),
+			"n2": node("UCSD"),
+		})},
+	)
+
+	got, err := run(t, NewGraphML(), types.StringUnknown(), types.DynamicValue(topo))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, perr := topology.ParseModel(got.(types.String).ValueString())
+	if perr != nil {
+		t.Fatal(perr)
+	}
+	for name, site := range map[string]string{"n1": "RENC", "n2": "UCSD"} {
+		v, ok := m.FindByName("NetworkNode", name)
+		if !ok {
+			t.Errorf("GraphML has no node %q", name)
+			continue
+		}
+		if v.Props["Site"] != site || v.Props["Type"] != "VM" {
+			t.Errorf("node %q = %v, want a VM at %s", name, v.Props, site)
+		}
+	}
+
+	// The same input gives the same document, graph id included.
+	again, _ := run(t, NewGraphML(), types.StringUnknown(), types.DynamicValue(topo))
+	if !again.Equal(got) {
+		t.Error("graphml is not deterministic")
+	}
+}
+
+func TestParseSliceModelInvalidXML(t *testing.T) {
+	for _, xml := range []string{"", "<graphml>", "not xml"} {
+		_, err := run(t, NewParseSliceModel(), types.ObjectUnknown(parsedModelType), types.StringValue(xml))
+		wantArgError(t, err, 0, "invalid GraphML")
+	}
+}
@@ -0,0 +1,105 @@
+package functions
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/resources/slice"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/topology"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &GraphML{}
+
+type GraphML struct{}
+
+func NewGraphML() function.Function { return &GraphML{} }
+
+func (f *GraphML) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "graphml"
+}
+
+func (f *GraphML) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Renders a fabric_slice topology as the GraphML request the provider would submit.",
+		MarkdownDescription: "Takes an object shaped like the `topology` attribute of `fabric_slice` " +
+			"(omitted attributes take the resource defaults), validates it and returns the GraphML request.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{Name: "topology", MarkdownDescription: "Topology object."},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *GraphML) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var arg types.Dynamic
+	resp.Error = req.Arguments.Get(ctx, &arg)
+	if resp.Error != nil {
+		return
+	}
+
+	raw, err := dynamicToJSON(ctx, arg)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	var in topologyArg
+	if err := json.Unmarshal(raw, &in); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "topology does not match the fabric_slice schema: "+err.Error())
+		return
+	}
+	topo := in.plan()
+
+	// Functions must be pure, so derive the graph id from the input.
+	graphID := uuid.NewSHA1(uuid.NameSpaceOID, raw).String()
+	_, graph, err := slice.BuildRequest(slice.Plan{Topology: topo}, graphID)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	out, err := topology.Marshal(graph)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, out)
+}
+
+// topologyArg mirrors the fabric_slice topology attribute, where nodes and
+// links are keyed by name.
+type topologyArg struct {
+	Nodes           map[string]slice.NodePlan  `json:"nodes"`
+	Links           map[string]slice.LinkPlan  `json:"links"`
+	FacilityPorts   []slice.FacilityPortPlan   `json:"facility_ports"`
+	NetworkServices []slice.NetworkServicePlan `json:"network_services"`
+}
+
+func (a topologyArg) plan() slice.TopologyPlan {
+	topo := slice.TopologyPlan{
+		FacilityPorts:   a.FacilityPorts,
+		NetworkServices: a.NetworkServices,
+	}
+	for _, name := range sortedNames(a.Nodes) {
+		n := a.Nodes[name]
+		n.Name = name
+		topo.Nodes = append(topo.Nodes, n)
+	}
+	for _, name := range sortedNames(a.Links) {
+		l := a.Links[name]
+		l.Name = name
+		topo.Links = append(topo.Links, l)
+	}
+	return topo
+}
+
+func sortedNames[V any](m map[string]V) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
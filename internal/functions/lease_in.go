@@ -0,0 +1,56 @@
+package functions
+
+import (
+	"context"
+	"time"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &LeaseIn{}
+
+type LeaseIn struct{}
+
+func NewLeaseIn() function.Function { return &LeaseIn{} }
+
+func (f *LeaseIn) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "lease_in"
+}
+
+func (f *LeaseIn) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Computes a lease end time relative to a start time.",
+		MarkdownDescription: "Returns a `lease_end_time` `duration` after `from` (e.g. `\"36h\"` or `\"14d\"`) in the orchestrator's format. " +
+			"The start time is an argument rather than an implicit \"now\" because provider functions must be pure: " +
+			"the same arguments have to give the same result in every plan and apply. " +
+			"Pass `plantimestamp()` as `from` for a lease counted from the current run.",
+		Parameters: []function.Parameter{
+			function.StringParameter{Name: "duration", MarkdownDescription: "Go duration or whole days, e.g. `24h`, `90m`, `7d`."},
+			function.StringParameter{Name: "from", MarkdownDescription: "RFC3339 start time, e.g. `plantimestamp()`."},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *LeaseIn) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var duration, from string
+	resp.Error = req.Arguments.Get(ctx, &duration, &from)
+	if resp.Error != nil {
+		return
+	}
+
+	d, err := utils.ParseLeaseDuration(duration)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	start, err := time.Parse(time.RFC3339, from)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, "from must be an RFC3339 timestamp")
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, utils.LeaseAfter(start, d))
+}
@@ -0,0 +1,101 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/topology"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &ParseSliceModel{}
+
+type ParseSliceModel struct{}
+
+func NewParseSliceModel() function.Function { return &ParseSliceModel{} }
+
+type parsedVertex struct {
+	ID         string            `tfsdk:"id"`
+	Class      string            `tfsdk:"class"`
+	Name       string            `tfsdk:"name"`
+	Type       string            `tfsdk:"type"`
+	Site       string            `tfsdk:"site"`
+	Properties map[string]string `tfsdk:"properties"`
+}
+
+type parsedEdge struct {
+	Source string `tfsdk:"source"`
+	Target string `tfsdk:"target"`
+	Class  string `tfsdk:"class"`
+}
+
+type parsedModel struct {
+	Vertices []parsedVertex `tfsdk:"vertices"`
+	Edges    []parsedEdge   `tfsdk:"edges"`
+}
+
+var parsedModelType = map[string]attr.Type{
+	"vertices": types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+		"id":         types.StringType,
+		"class":      types.StringType,
+		"name":       types.StringType,
+		"type":       types.StringType,
+		"site":       types.StringType,
+		"properties": types.MapType{ElemType: types.StringType},
+	}}},
+	"edges": types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+		"source": types.StringType,
+		"target": types.StringType,
+		"class":  types.StringType,
+	}}},
+}
+
+func (f *ParseSliceModel) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_slice_model"
+}
+
+func (f *ParseSliceModel) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Decodes a GraphML slice model into vertices and edges.",
+		MarkdownDescription: "Parses GraphML (e.g. `fabric_slice.graph_model`) and returns `vertices` " +
+			"(`id`, `class`, `name`, `type`, `site` and all raw `properties`) and `edges` (`source`, `target`, `class`).",
+		Parameters: []function.Parameter{
+			function.StringParameter{Name: "xml", MarkdownDescription: "GraphML document."},
+		},
+		Return: function.ObjectReturn{AttributeTypes: parsedModelType},
+	}
+}
+
+func (f *ParseSliceModel) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var xml string
+	resp.Error = req.Arguments.Get(ctx, &xml)
+	if resp.Error != nil {
+		return
+	}
+
+	m, err := topology.ParseModel(xml)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "invalid GraphML: "+err.Error())
+		return
+	}
+
+	out := parsedModel{
+		Vertices: make([]parsedVertex, 0, len(m.Nodes)),
+		Edges:    make([]parsedEdge, 0, len(m.Edges)),
+	}
+	for _, n := range m.Nodes {
+		out.Vertices = append(out.Vertices, parsedVertex{
+			ID:         n.ID,
+			Class:      n.Props["Class"],
+			Name:       n.Props["Name"],
+			Type:       n.Props["Type"],
+			Site:       n.Props["Site"],
+			Properties: n.Props,
+		})
+	}
+	for _, e := range m.Edges {
+		out.Edges = append(out.Edges, parsedEdge{Source: e.Source, Target: e.Target, Class: e.Props["Class"]})
+	}
+	resp.Error = resp.Result.Set(ctx, out)
+}
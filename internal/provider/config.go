@@ -1,10 +1,20 @@
 package provider
 
 import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
 	"os"
+	"time"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/clients/orchestrator"
 )
 
-const defaultEndpoint = "https://orchestrator.fabric-testbed.net"
+const (
+	defaultBastionHost    = "bastion.fabric-testbed.net"
+	defaultRequestTimeout = 5 * time.Minute
+)
 
 func getenvOr(k, def string) string {
 	if v := os.Getenv(k); v != "" {
@@ -12,3 +22,57 @@ func getenvOr(k, def string) string {
 	}
 	return def
 }
+
+// httpConfig builds the HTTP settings shared by all FABRIC API clients.
+func httpConfig(cfg FabricProviderModel, version string) (orchestrator.HTTPConfig, error) {
+	h := orchestrator.HTTPConfig{
+		Timeout:            defaultRequestTimeout,
+		InsecureSkipVerify: cfg.InsecureSkipVerify.ValueBool(),
+		UserAgent:          "terraform-provider-fabric/" + version,
+	}
+
+	if s := cfg.RequestTimeout.ValueString(); s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil || d < 0 {
+			return h, fmt.Errorf("request_timeout: %q is not a valid duration (e.g. \"90s\", \"5m\")", s)
+		}
+		h.Timeout = d
+	}
+
+	if s := cfg.ProxyURL.ValueString(); s != "" {
+		u, err := url.Parse(s)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return h, fmt.Errorf("proxy_url: %q is not a valid URL", s)
+		}
+		h.ProxyURL = u
+	}
+
+	var pems [][]byte
+	if f := cfg.CACertFile.ValueString(); f != "" {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			return h, fmt.Errorf("ca_cert_file: %w", err)
+		}
+		pems = append(pems, b)
+	}
+	if s := cfg.CACertPEM.ValueString(); s != "" {
+		pems = append(pems, []byte(s))
+	}
+	if len(pems) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		for _, b := range pems {
+			if !pool.AppendCertsFromPEM(b) {
+				return h, errors.New("ca_cert_file/ca_cert_pem: no PEM certificates found")
+			}
+		}
+		h.RootCAs = pool
+	}
+
+	if s := cfg.UserAgent.ValueString(); s != "" {
+		h.UserAgent = s
+	}
+	return h, nil
+}
@@ -2,14 +2,28 @@ package provider
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/clients/coreapi"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/clients/credmgr"
 	"github.com/csc478-wcu/terraform-provider-fabric/internal/clients/orchestrator"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/clients/sshexec"
+	facilityportsds "github.com/csc478-wcu/terraform-provider-fabric/internal/datasources/facilityports"
 	resourcesds "github.com/csc478-wcu/terraform-provider-fabric/internal/datasources/resources"
 	sitesds "github.com/csc478-wcu/terraform-provider-fabric/internal/datasources/sites"
+	tokeneph "github.com/csc478-wcu/terraform-provider-fabric/internal/ephemeral/token"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/functions"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/resources/networkservice"
 	"github.com/csc478-wcu/terraform-provider-fabric/internal/resources/slice"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/resources/slicenode"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/resources/sshkey"
 	"github.com/csc478-wcu/terraform-provider-fabric/internal/runtime"
 	"github.com/csc478-wcu/terraform-provider-fabric/internal/services"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/topology"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	pframework "github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -17,15 +31,37 @@ import (
 )
 
 var _ pframework.Provider = &FabricProvider{}
+var _ pframework.ProviderWithFunctions = &FabricProvider{}
+var _ pframework.ProviderWithEphemeralResources = &FabricProvider{}
 
 type FabricProvider struct {
 	version string
 }
 
 type FabricProviderModel struct {
-	Token    types.String `tfsdk:"token"`
-	Endpoint types.String `tfsdk:"endpoint"`
-	SSHKey   types.String `tfsdk:"ssh_key"`
+	Token       types.String `tfsdk:"token"`
+	Endpoint    types.String `tfsdk:"endpoint"`
+	SSHKey      types.String `tfsdk:"ssh_key"`
+	GraphFormat types.String `tfsdk:"graph_format"`
+
+	RefreshToken    types.String `tfsdk:"refresh_token"`
+	CredmgrEndpoint types.String `tfsdk:"credmgr_endpoint"`
+	CoreAPIEndpoint types.String `tfsdk:"core_api_endpoint"`
+	ProjectID       types.String `tfsdk:"project_id"`
+
+	BastionHost     types.String `tfsdk:"bastion_host"`
+	BastionUsername types.String `tfsdk:"bastion_username"`
+
+	BastionPrivateKey types.String `tfsdk:"bastion_private_key"`
+	SlicePrivateKey   types.String `tfsdk:"slice_private_key"`
+	KnownHostsFile    types.String `tfsdk:"known_hosts_file"`
+
+	RequestTimeout     types.String `tfsdk:"request_timeout"`
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	UserAgent          types.String `tfsdk:"user_agent"`
 }
 
 func (p *FabricProvider) Metadata(_ context.Context, req pframework.MetadataRequest, resp *pframework.MetadataResponse) {
@@ -50,6 +86,73 @@ func (p *FabricProvider) Schema(_ context.Context, _ pframework.SchemaRequest, r
 				MarkdownDescription: "Default SSH public key (or FABRIC_SSH_KEY).",
 				Optional:            true,
 			},
+			"refresh_token": schema.StringAttribute{
+				MarkdownDescription: "FABRIC refresh token (or FABRIC_REFRESH_TOKEN). Used to mint an id_token when `token` is not set, and by `fabric_token`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"credmgr_endpoint": schema.StringAttribute{
+				MarkdownDescription: "FABRIC Credential Manager endpoint.",
+				Optional:            true,
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "FABRIC project (or FABRIC_PROJECT_ID). Tokens minted from `refresh_token` are scoped to it and slice lookups are limited to it. When unset, the token's default project is used.",
+				Optional:            true,
+			},
+			"core_api_endpoint": schema.StringAttribute{
+				MarkdownDescription: "FABRIC Core API endpoint, used to manage SSH keys.",
+				Optional:            true,
+			},
+			"bastion_host": schema.StringAttribute{
+				MarkdownDescription: "Bastion host used in generated SSH settings. Defaults to `bastion.fabric-testbed.net`.",
+				Optional:            true,
+			},
+			"bastion_username": schema.StringAttribute{
+				MarkdownDescription: "Bastion login (or FABRIC_BASTION_USERNAME).",
+				Optional:            true,
+			},
+			"bastion_private_key": schema.StringAttribute{
+				MarkdownDescription: "PEM private key for the bastion host (or FABRIC_BASTION_KEY). Used to run `post_boot_script`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"slice_private_key": schema.StringAttribute{
+				MarkdownDescription: "PEM private key matching the slice's SSH keys (or FABRIC_SLICE_PRIVATE_KEY). Used to run `post_boot_script`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"known_hosts_file": schema.StringAttribute{
+				MarkdownDescription: "OpenSSH known_hosts file (or FABRIC_KNOWN_HOSTS_FILE) used when running `post_boot_script`. The bastion's host key must be listed in it; slice nodes are checked when listed, since they get new host keys on every create. When unset, host keys are not checked.",
+				Optional:            true,
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "Timeout for each API request, as a Go duration. Defaults to `5m`.",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "HTTP(S) proxy for API requests. Defaults to the `HTTPS_PROXY`/`HTTP_PROXY` environment.",
+				Optional:            true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "PEM file with extra CA certificates to trust.",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded extra CA certificates to trust.",
+				Optional:            true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip TLS certificate verification. Only for test deployments.",
+				Optional:            true,
+			},
+			"user_agent": schema.StringAttribute{
+				MarkdownDescription: "User-Agent sent with API requests. Defaults to `terraform-provider-fabric/<version>`.",
+				Optional:            true,
+			},
+			"graph_format": schema.StringAttribute{
+				MarkdownDescription: "Format slice models are requested in: `GRAPHML` (default), `JSON_NODELINK` or `CYTOSCAPE`.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -61,17 +164,46 @@ func (p *FabricProvider) Configure(ctx context.Context, req pframework.Configure
 		return
 	}
 
+	httpCfg, err := httpConfig(cfg, p.version)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid HTTP settings", err.Error())
+		return
+	}
+
+	refreshToken := getenvOr("FABRIC_REFRESH_TOKEN", "")
+	if !cfg.RefreshToken.IsNull() && cfg.RefreshToken.ValueString() != "" {
+		refreshToken = cfg.RefreshToken.ValueString()
+	}
+	credmgrEndpoint := credmgr.DefaultEndpoint
+	if !cfg.CredmgrEndpoint.IsNull() && cfg.CredmgrEndpoint.ValueString() != "" {
+		credmgrEndpoint = cfg.CredmgrEndpoint.ValueString()
+	}
+	tokensSvc := services.NewTokensService(credmgr.New(credmgr.Config{Endpoint: credmgrEndpoint, HTTPClient: httpCfg.Client()}))
+
+	projectID := getenvOr("FABRIC_PROJECT_ID", "")
+	if !cfg.ProjectID.IsNull() && cfg.ProjectID.ValueString() != "" {
+		projectID = cfg.ProjectID.ValueString()
+	}
+
 	token := getenvOr("FABRIC_TOKEN", "")
 	if !cfg.Token.IsNull() {
 		token = cfg.Token.ValueString()
 	}
+	if token == "" && refreshToken != "" {
+		tok, err := tokensSvc.Refresh(ctx, refreshToken, projectID, "")
+		if err != nil {
+			resp.Diagnostics.AddError("Token refresh failed", err.Error())
+			return
+		}
+		token = tok.IDToken
+	}
 	if token == "" {
 		resp.Diagnostics.AddError("Missing API Token",
-			"Provide 'token' in configuration or set FABRIC_TOKEN.")
+			"Provide 'token' or 'refresh_token' in configuration or set FABRIC_TOKEN / FABRIC_REFRESH_TOKEN.")
 		return
 	}
 
-	endpoint := defaultEndpoint
+	endpoint := orchestrator.DefaultEndpoint
 	if !cfg.Endpoint.IsNull() && cfg.Endpoint.ValueString() != "" {
 		endpoint = cfg.Endpoint.ValueString()
 	}
@@ -80,27 +212,112 @@ func (p *FabricProvider) Configure(ctx context.Context, req pframework.Configure
 		sshKey = cfg.SSHKey.ValueString()
 	}
 
+	bastionHost := defaultBastionHost
+	if !cfg.BastionHost.IsNull() && cfg.BastionHost.ValueString() != "" {
+		bastionHost = cfg.BastionHost.ValueString()
+	}
+	bastionUser := getenvOr("FABRIC_BASTION_USERNAME", "")
+	if !cfg.BastionUsername.IsNull() && cfg.BastionUsername.ValueString() != "" {
+		bastionUser = cfg.BastionUsername.ValueString()
+	}
+
+	var execSvc services.ExecService
+	bastionKey := getenvOr("FABRIC_BASTION_KEY", "")
+	if !cfg.BastionPrivateKey.IsNull() && cfg.BastionPrivateKey.ValueString() != "" {
+		bastionKey = cfg.BastionPrivateKey.ValueString()
+	}
+	sliceKey := getenvOr("FABRIC_SLICE_PRIVATE_KEY", "")
+	if !cfg.SlicePrivateKey.IsNull() && cfg.SlicePrivateKey.ValueString() != "" {
+		sliceKey = cfg.SlicePrivateKey.ValueString()
+	}
+	knownHosts := getenvOr("FABRIC_KNOWN_HOSTS_FILE", "")
+	if !cfg.KnownHostsFile.IsNull() && cfg.KnownHostsFile.ValueString() != "" {
+		knownHosts = cfg.KnownHostsFile.ValueString()
+	}
+	if sliceKey != "" {
+		sshClient, err := sshexec.New(sshexec.Config{
+			BastionHost:     bastionHost,
+			BastionUsername: bastionUser,
+			BastionKey:      bastionKey,
+			NodeKey:         sliceKey,
+			KnownHostsFile:  knownHosts,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid SSH settings", err.Error())
+			return
+		}
+		execSvc = services.NewExecService(sshClient)
+	}
+
+	graphFormat := topology.FormatGraphML
+	if !cfg.GraphFormat.IsNull() && cfg.GraphFormat.ValueString() != "" {
+		graphFormat = cfg.GraphFormat.ValueString()
+	}
+	switch graphFormat {
+	case topology.FormatGraphML, topology.FormatJSONNodeLink, topology.FormatCytoscape:
+	default:
+		resp.Diagnostics.AddError("Invalid graph_format",
+			fmt.Sprintf("graph_format must be GRAPHML, JSON_NODELINK or CYTOSCAPE, got %q.", graphFormat))
+		return
+	}
+
+	// One lock set for every slices service: a slice is the same slice
+	// whichever project token reaches it.
+	sliceLocks := &utils.KeyedMutex{}
+	newSlices := func(token, projectID string) services.SlicesService {
+		return services.NewSlicesService(orchestrator.New(orchestrator.Config{
+			Endpoint:    endpoint,
+			Token:       token,
+			GraphFormat: graphFormat,
+			ProjectID:   projectID,
+			HTTP:        httpCfg,
+		}), sliceLocks)
+	}
 	orc := orchestrator.New(orchestrator.Config{
-		Endpoint: endpoint,
-		Token:    token,
+		Endpoint:    endpoint,
+		Token:       token,
+		GraphFormat: graphFormat,
+		ProjectID:   projectID,
+		HTTP:        httpCfg,
 	})
-	slicesSvc := services.NewSlicesService(orc)
+	slicesSvc := services.NewSlicesService(orc, sliceLocks)
 	resSvc := services.NewResourcesService(orc)
 
+	coreEndpoint := coreapi.DefaultEndpoint
+	if !cfg.CoreAPIEndpoint.IsNull() && cfg.CoreAPIEndpoint.ValueString() != "" {
+		coreEndpoint = cfg.CoreAPIEndpoint.ValueString()
+	}
+	sshKeysSvc := services.NewSSHKeysService(coreapi.New(coreapi.Config{Endpoint: coreEndpoint, Token: token, HTTPClient: httpCfg.Client()}))
+
 	deps := &runtime.Deps{
 		Slices:        slicesSvc,
 		Resources:     resSvc,
+		Tokens:        tokensSvc,
+		SSHKeys:       sshKeysSvc,
+		Exec:          execSvc,
 		DefaultSSHKey: sshKey,
 		Endpoint:      endpoint,
+		RefreshToken:  refreshToken,
+		ProjectID:     projectID,
+
+		BastionHost:     bastionHost,
+		BastionUsername: bastionUser,
+
+		NewSlices:  newSlices,
+		SliceLocks: sliceLocks,
 	}
 
 	resp.DataSourceData = deps
 	resp.ResourceData = deps
+	resp.EphemeralResourceData = deps
 }
 
 func (p *FabricProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		func() resource.Resource { return slice.New() },
+		func() resource.Resource { return sshkey.New() },
+		func() resource.Resource { return slicenode.New() },
+		func() resource.Resource { return networkservice.New() },
 	}
 }
 
@@ -108,6 +325,22 @@ func (p *FabricProvider) DataSources(_ context.Context) []func() datasource.Data
 	return []func() datasource.DataSource{
 		func() datasource.DataSource { return resourcesds.New() },
 		func() datasource.DataSource { return sitesds.New() },
+		func() datasource.DataSource { return facilityportsds.New() },
+	}
+}
+
+func (p *FabricProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		func() ephemeral.EphemeralResource { return tokeneph.New() },
+	}
+}
+
+func (p *FabricProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		functions.NewFlavor,
+		functions.NewLeaseIn,
+		functions.NewGraphML,
+		functions.NewParseSliceModel,
 	}
 }
 
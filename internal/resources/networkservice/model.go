@@ -0,0 +1,48 @@
+package networkservice
+
+import (
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/resources/slice"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type Model struct {
+	ID      types.String `tfsdk:"id"`
+	SliceID types.String `tfsdk:"slice_id"`
+
+	Name       types.String        `tfsdk:"name"`
+	Type       types.String        `tfsdk:"type"`
+	Subnet     types.String        `tfsdk:"subnet"`
+	Gateway    types.String        `tfsdk:"gateway"`
+	Interfaces []slice.TFInterface `tfsdk:"interfaces"`
+
+	Site            types.String `tfsdk:"site"`
+	MirrorPort      types.String `tfsdk:"mirror_port"`
+	MirrorDirection types.String `tfsdk:"mirror_direction"`
+
+	ProjectID types.String `tfsdk:"project_id"`
+	Endpoint  types.String `tfsdk:"endpoint"`
+}
+
+func (m Model) service() slice.TFNetworkService {
+	return slice.TFNetworkService{
+		Name:            m.Name,
+		Type:            m.Type,
+		Subnet:          m.Subnet,
+		Gateway:         m.Gateway,
+		Interfaces:      m.Interfaces,
+		Site:            m.Site,
+		MirrorPort:      m.MirrorPort,
+		MirrorDirection: m.MirrorDirection,
+	}
+}
+
+func (m *Model) setService(ns slice.TFNetworkService) {
+	m.Name = ns.Name
+	m.Type = ns.Type
+	m.Subnet = ns.Subnet
+	m.Gateway = ns.Gateway
+	m.Interfaces = ns.Interfaces
+	m.Site = ns.Site
+	m.MirrorPort = ns.MirrorPort
+	m.MirrorDirection = ns.MirrorDirection
+}
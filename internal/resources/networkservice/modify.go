@@ -0,0 +1,90 @@
+package networkservice
+
+import (
+	"fmt"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/resources/slice"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/topology"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// addService validates svc against the nodes, switches and facility ports
+// already in the slice model and merges it into m. It returns the service
+// with defaults applied.
+func addService(m *topology.Model, svc slice.NetworkServicePlan) (slice.NetworkServicePlan, error) {
+	var topo slice.TopologyPlan
+	for _, n := range m.Nodes {
+		if n.Props["Class"] != "NetworkNode" {
+			continue
+		}
+		name, site := n.Props["Name"], n.Props["Site"]
+		switch n.Props["Type"] {
+		case "Facility":
+			topo.FacilityPorts = append(topo.FacilityPorts, slice.FacilityPortPlan{Name: name, Site: site})
+		case "Switch":
+			topo.Nodes = append(topo.Nodes, slice.NodePlan{Name: name, Site: site, Type: "Switch"})
+		default:
+			topo.Nodes = append(topo.Nodes, slice.NodePlan{Name: name, Site: site, Type: "VM"})
+		}
+	}
+	topo.NetworkServices = []slice.NetworkServicePlan{svc}
+
+	pNorm, graph, err := slice.BuildRequest(slice.Plan{Topology: topo}, m.GraphID())
+	if err != nil {
+		return svc, err
+	}
+	svc = pNorm.Topology.NetworkServices[0]
+
+	if _, ok := m.FindByName("NetworkService", svc.Name); ok {
+		return svc, fmt.Errorf("slice already has a network service named %q", svc.Name)
+	}
+	facilityPorts := make(map[string]bool, len(topo.FacilityPorts))
+	for _, fp := range topo.FacilityPorts {
+		facilityPorts[fp.Name] = true
+	}
+	for _, ifc := range svc.Interfaces {
+		if facilityPorts[ifc.Node] {
+			continue // reuses the port's own interface
+		}
+		if _, ok := m.FindByName("ConnectionPoint", ifc.Name); ok {
+			return svc, fmt.Errorf("slice already has an interface named %q", ifc.Name)
+		}
+	}
+
+	m.Merge(topology.Decode(graph))
+	return svc, nil
+}
+
+// interfacesFromModel rebuilds the interfaces of an imported service from
+// the connection points it connects to.
+func interfacesFromModel(m *topology.Model, svc topology.ModelNode) []slice.TFInterface {
+	var out []slice.TFInterface
+	for _, e := range m.Edges {
+		if e.Source != svc.ID || e.Props["Class"] != "connects" {
+			continue
+		}
+		cp, ok := m.Node(e.Target)
+		if !ok {
+			continue
+		}
+		ifc := slice.TFInterface{
+			Name:      types.StringValue(cp.Props["Name"]),
+			VLAN:      types.StringNull(),
+			Bandwidth: types.Int64Null(),
+			MAC:       types.StringNull(),
+			IPAddr:    types.StringNull(),
+			NICModel:  types.StringNull(),
+		}
+		owner, ok := m.Parent(cp.ID)
+		if ok && owner.Props["Class"] == "Component" {
+			ifc.NICModel = types.StringValue(topology.NICModel(owner))
+			owner, ok = m.Parent(owner.ID)
+		}
+		if !ok {
+			continue
+		}
+		ifc.Node = types.StringValue(owner.Props["Name"])
+		out = append(out, ifc)
+	}
+	return out
+}
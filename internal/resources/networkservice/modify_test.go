@@ -0,0 +1,128 @@
+package networkservice
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/resources/slice"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/topology"
+)
+
+// sliceModel is a slice with two VMs and a facility port, the first VM
+// connected to the port by service "net".
+func sliceModel(t *testing.T) *topology.Model {
+	t.Helper()
+	_, graph, err := slice.BuildRequest(slice.Plan{Topology: slice.TopologyPlan{
+		Nodes: []slice.NodePlan{
+			{Name: "n1", Site: "RENC"},
+			{Name: "n2", Site: "RENC"},
+		},
+		FacilityPorts: []slice.FacilityPortPlan{{Name: "fp1", Site: "RENC", VLAN: "100"}},
+		NetworkServices: []slice.NetworkServicePlan{{
+			Name:       "net",
+			Interfaces: []slice.InterfacePlan{{Node: "n1"}, {Node: "fp1"}},
+		}},
+	}}, "g1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return topology.Decode(graph)
+}
+
+// connected returns the connection points service name connects to, keyed
+// by the name of the node, switch or facility port owning them.
+func connected(t *testing.T, m *topology.Model, name string) map[string]topology.ModelNode {
+	t.Helper()
+	svc, ok := m.FindByName("NetworkService", name)
+	if !ok {
+		t.Fatalf("no service %q in model", name)
+	}
+	out := map[string]topology.ModelNode{}
+	for _, e := range m.Edges {
+		if e.Source != svc.ID || e.Props["Class"] != "connects" {
+			continue
+		}
+		cp, _ := m.Node(e.Target)
+		owner, _ := m.Parent(cp.ID)
+		if owner.Props["Class"] == "Component" {
+			owner, _ = m.Parent(owner.ID)
+		}
+		out[owner.Props["Name"]] = cp
+	}
+	return out
+}
+
+func TestAddServiceReusesFacilityPort(t *testing.T) {
+	m := sliceModel(t)
+	before := connected(t, m, "net")
+
+	svc, err := addService(m, slice.NetworkServicePlan{
+		Name:       "net2",
+		Interfaces: []slice.InterfacePlan{{Node: "n2"}, {Node: "fp1"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if svc.Type != "L2Bridge" || svc.Interfaces[0].Name == "" {
+		t.Errorf("defaults not applied: %+v", svc)
+	}
+
+	after := connected(t, m, "net2")
+	if len(after) != 2 || after["n2"].ID == "" {
+		t.Fatalf("net2 connects to %v, want n2 and fp1", after)
+	}
+	if got, want := after["fp1"].ID, before["fp1"].ID; got != want {
+		t.Errorf("net2 uses facility port interface %s, want the existing %s", got, want)
+	}
+	var fpInterfaces int
+	fp, _ := m.FindByName("NetworkNode", "fp1")
+	for _, c := range m.Children(fp.ID) {
+		if c.Props["Class"] == "ConnectionPoint" {
+			fpInterfaces++
+		}
+	}
+	if fpInterfaces != 1 {
+		t.Errorf("fp1 has %d interfaces after the merge, want 1", fpInterfaces)
+	}
+}
+
+func TestAddServiceRejectsClashes(t *testing.T) {
+	existing := connected(t, sliceModel(t), "net")["n1"].Props["Name"]
+	if existing == "" {
+		t.Fatal("net has no interface on n1")
+	}
+
+	for _, tc := range []struct {
+		name string
+		svc  slice.NetworkServicePlan
+		want string
+	}{
+		{
+			"service name",
+			slice.NetworkServicePlan{Name: "net", Interfaces: []slice.InterfacePlan{{Node: "n2"}}},
+			`network service named "net"`,
+		},
+		{
+			"interface name",
+			slice.NetworkServicePlan{Name: "net2", Interfaces: []slice.InterfacePlan{{Name: existing, Node: "n2"}}},
+			`interface named "` + existing + `"`,
+		},
+		{
+			"unknown node",
+			slice.NetworkServicePlan{Name: "net2", Interfaces: []slice.InterfacePlan{{Node: "n9"}}},
+			"n9",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := sliceModel(t)
+			nodes, edges := len(m.Nodes), len(m.Edges)
+			_, err := addService(m, tc.svc)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("error = %v, want one mentioning %s", err, tc.want)
+			}
+			if len(m.Nodes) != nodes || len(m.Edges) != edges {
+				t.Error("model changed although the service was rejected")
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package networkservice
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/clients/orchestrator"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/resources/slice"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/runtime"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/services"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/topology"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	rframework "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ rframework.Resource = &Resource{}
+var _ rframework.ResourceWithImportState = &Resource{}
+
+type Resource struct {
+	deps *runtime.Deps
+}
+
+func New() rframework.Resource { return &Resource{} }
+
+func (r *Resource) Metadata(_ context.Context, req rframework.MetadataRequest, resp *rframework.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_network_service"
+}
+
+func (r *Resource) Schema(_ context.Context, _ rframework.SchemaRequest, resp *rframework.SchemaResponse) {
+	resp.Schema = Schema()
+}
+
+func (r *Resource) Configure(_ context.Context, req rframework.ConfigureRequest, resp *rframework.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d, ok := req.ProviderData.(*runtime.Deps)
+	if !ok {
+		resp.Diagnostics.AddError("Internal error", fmt.Sprintf("unexpected provider deps type %T", req.ProviderData))
+		return
+	}
+	r.deps = d
+}
+
+func (r *Resource) Create(ctx context.Context, req rframework.CreateRequest, resp *rframework.CreateResponse) {
+	var plan Model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sliceID := plan.SliceID.ValueString()
+	svc := slice.NetworkServiceFromTF(plan.service())
+
+	slices, err := r.deps.SlicesFor(ctx, plan.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project", err.Error())
+		return
+	}
+	sl, err := slices.Edit(ctx, sliceID, func(m *topology.Model) error {
+		var err error
+		svc, err = addService(m, svc)
+		return err
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Add network service failed", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(r.setState(ctx, &resp.State, plan, svc, sl, "adding")...)
+}
+
+func (r *Resource) Read(ctx context.Context, req rframework.ReadRequest, resp *rframework.ReadResponse) {
+	var state Model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sliceID, name := state.SliceID.ValueString(), state.Name.ValueString()
+
+	if !slice.CheckEndpoint(r.deps, sliceID, state.Endpoint, &resp.Diagnostics) {
+		return
+	}
+	slices, err := slice.StateSlices(ctx, r.deps, state.ProjectID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project", err.Error())
+		return
+	}
+	sl, err := slices.Get(ctx, sliceID)
+	if err != nil {
+		if services.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Read slice failed", err.Error())
+		return
+	}
+	if services.IsGone(sl.State) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	m, err := topology.ParseModelFormat(sl.ModelFormat, sl.Model)
+	if err != nil {
+		resp.Diagnostics.AddError("Could not decode slice model", err.Error())
+		return
+	}
+	v, ok := m.FindByName("NetworkService", name)
+	if !ok {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ID = types.StringValue(sliceID + "/" + name)
+	state.ProjectID = slice.SliceProject(r.deps, state.ProjectID, sl)
+	state.Endpoint = types.StringValue(r.deps.Endpoint) // imported or older state
+	if state.Type.IsNull() || state.Type.IsUnknown() {
+		state.Type = types.StringValue(v.Props["Type"])
+	}
+	if state.Interfaces == nil { // imported
+		state.Interfaces = interfacesFromModel(m, v)
+	}
+	topo := &slice.TFTopology{NetworkServices: []slice.TFNetworkService{state.service()}}
+	resp.Diagnostics.Append(slice.ApplySliceModel(topo, sl.Model, sl.ModelFormat)...)
+	state.setService(topo.NetworkServices[0])
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update replaces the service in a single modify: the old service and its
+// interfaces are removed and the new one added in the same request.
+func (r *Resource) Update(ctx context.Context, req rframework.UpdateRequest, resp *rframework.UpdateResponse) {
+	var plan, state Model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sliceID := plan.SliceID.ValueString()
+	svc := slice.NetworkServiceFromTF(plan.service())
+
+	if !slice.CheckEndpoint(r.deps, sliceID, state.Endpoint, &resp.Diagnostics) {
+		return
+	}
+	slices, err := slice.StateSlices(ctx, r.deps, state.ProjectID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project", err.Error())
+		return
+	}
+	sl, err := slices.Edit(ctx, sliceID, func(m *topology.Model) error {
+		if v, ok := m.FindByName("NetworkService", state.Name.ValueString()); ok {
+			m.RemoveService(v.ID)
+		}
+		var err error
+		svc, err = addService(m, svc)
+		return err
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Update network service failed", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(r.setState(ctx, &resp.State, plan, svc, sl, "updating")...)
+}
+
+func (r *Resource) Delete(ctx context.Context, req rframework.DeleteRequest, resp *rframework.DeleteResponse) {
+	var state Model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sliceID, name := state.SliceID.ValueString(), state.Name.ValueString()
+
+	if !slice.CheckEndpoint(r.deps, sliceID, state.Endpoint, &resp.Diagnostics) {
+		return
+	}
+	slices, err := slice.StateSlices(ctx, r.deps, state.ProjectID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project", err.Error())
+		return
+	}
+	removed := false
+	sl, err := slices.Edit(ctx, sliceID, func(m *topology.Model) error {
+		v, ok := m.FindByName("NetworkService", name)
+		if !ok {
+			return services.ErrNoChange
+		}
+		m.RemoveService(v.ID)
+		removed = true
+		return nil
+	})
+	if err != nil {
+		if services.IsNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Remove network service failed", err.Error())
+		return
+	}
+	if removed && !services.ModifySucceeded(sl.State) {
+		resp.Diagnostics.AddError("Remove network service failed",
+			fmt.Sprintf("Slice %s settled in state %s after removing service %q.", sliceID, sl.State, name))
+	}
+}
+
+// ImportState takes "<slice_id>/<name>".
+func (r *Resource) ImportState(ctx context.Context, req rframework.ImportStateRequest, resp *rframework.ImportStateResponse) {
+	sliceID, name, ok := strings.Cut(req.ID, "/")
+	if !ok || sliceID == "" || name == "" {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("Expected <slice_id>/<name>, got %q.", req.ID))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("slice_id"), sliceID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
+
+// setState records the normalized service with the addressing the
+// orchestrator assigned. The service was submitted either way, so a failed
+// modify is recorded too and taints the resource.
+func (r *Resource) setState(ctx context.Context, st *tfsdk.State, plan Model, svc slice.NetworkServicePlan, sl orchestrator.Slice, verb string) diag.Diagnostics {
+	topo := &slice.TFTopology{NetworkServices: []slice.TFNetworkService{slice.NetworkServiceToTF(svc)}}
+	diags := slice.ApplySliceModel(topo, sl.Model, sl.ModelFormat)
+	plan.setService(topo.NetworkServices[0])
+	plan.ID = types.StringValue(plan.SliceID.ValueString() + "/" + svc.Name)
+	plan.ProjectID = slice.SliceProject(r.deps, plan.ProjectID, sl)
+	plan.Endpoint = types.StringValue(r.deps.Endpoint)
+
+	diags.Append(st.Set(ctx, &plan)...)
+	if !services.ModifySucceeded(sl.State) {
+		diags.AddError("Modify slice failed",
+			fmt.Sprintf("Slice %s settled in state %s after %s service %q.", plan.SliceID.ValueString(), sl.State, verb, svc.Name))
+	}
+	return diags
+}
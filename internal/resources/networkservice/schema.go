@@ -0,0 +1,37 @@
+package networkservice
+
+import (
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/resources/slice"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+)
+
+func Schema() schema.Schema {
+	attrs := slice.NetworkServiceAttributes()
+	attrs["id"] = schema.StringAttribute{
+		MarkdownDescription: "`<slice_id>/<name>`.",
+		Computed:            true,
+	}
+	attrs["slice_id"] = schema.StringAttribute{
+		MarkdownDescription: "ID of the slice the service belongs to. Its interfaces can attach to any node, switch or facility port already in the slice.",
+		Required:            true,
+		PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+	}
+	attrs["project_id"] = schema.StringAttribute{
+		MarkdownDescription: "FABRIC project owning the slice. Defaults to the provider's `project_id`; a different project needs the provider's `refresh_token`.",
+		Optional:            true,
+		Computed:            true,
+		PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace(), stringplanmodifier.UseStateForUnknown()},
+	}
+	attrs["endpoint"] = schema.StringAttribute{
+		MarkdownDescription: "Orchestrator endpoint the service was added through. Providers configured for another endpoint refuse to manage the service.",
+		Computed:            true,
+		PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+	}
+
+	return schema.Schema{
+		MarkdownDescription: "Adds a network service to an existing `fabric_slice` through the slice modify API. Changes remove and re-add the service in a single modify.",
+		Attributes:          attrs,
+	}
+}
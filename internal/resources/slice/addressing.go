@@ -0,0 +1,74 @@
+package slice
+
+import (
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/topology"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ApplySliceModel copies orchestrator-assigned addressing (node management
+// IPs, service subnet and gateway, interface IPs) from the slice's GraphML
+// model into topo. Configured interface addresses are kept; values the model
+// doesn't carry are left as they are, except that unknowns become null.
+func ApplySliceModel(topo *TFTopology, model, format string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if topo == nil {
+		return diags
+	}
+
+	var m *topology.Model
+	if model != "" {
+		parsed, err := topology.ParseModelFormat(format, model)
+		if err != nil {
+			diags.AddWarning("Could not decode slice model", err.Error())
+		} else {
+			m = parsed
+		}
+	}
+
+	for name, n := range topo.Nodes {
+		if m != nil {
+			if v, ok := m.FindByName("NetworkNode", name); ok {
+				if ip := v.ManagementIP(); ip != "" {
+					n.ManagementIP = types.StringValue(ip)
+				}
+			}
+		}
+		n.ManagementIP = nullIfUnknown(n.ManagementIP)
+		topo.Nodes[name] = n
+	}
+
+	for i := range topo.NetworkServices {
+		ns := &topo.NetworkServices[i]
+		if m != nil {
+			if v, ok := m.FindByName("NetworkService", toString(ns.Name)); ok {
+				if subnet, gw := v.Gateway(); subnet != "" || gw != "" {
+					ns.Subnet = types.StringValue(subnet)
+					ns.Gateway = types.StringValue(gw)
+				}
+			}
+		}
+		ns.Subnet = nullIfUnknown(ns.Subnet)
+		ns.Gateway = nullIfUnknown(ns.Gateway)
+
+		for j := range ns.Interfaces {
+			ifc := &ns.Interfaces[j]
+			if m != nil && (ifc.IPAddr.IsNull() || ifc.IPAddr.IsUnknown()) {
+				if v, ok := m.FindByName("ConnectionPoint", toString(ifc.Name)); ok {
+					if ip := v.IPAddr(); ip != "" {
+						ifc.IPAddr = types.StringValue(ip)
+					}
+				}
+			}
+			ifc.IPAddr = nullIfUnknown(ifc.IPAddr)
+		}
+	}
+	return diags
+}
+
+func nullIfUnknown(v types.String) types.String {
+	if v.IsUnknown() {
+		return types.StringNull()
+	}
+	return v
+}
@@ -0,0 +1,170 @@
+package slice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/clients/orchestrator"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/services"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/topology"
+)
+
+// activeNamesake returns an active slice named name, if there is one.
+// FABRIC allows one active slice per name.
+func activeNamesake(ctx context.Context, slices services.SlicesService, name string) (orchestrator.Slice, bool, error) {
+	found, err := slices.List(ctx, name, services.ActiveStates)
+	if err != nil {
+		return orchestrator.Slice{}, false, err
+	}
+	for _, sl := range found {
+		if sl.Name == name {
+			return sl, true, nil
+		}
+	}
+	return orchestrator.Slice{}, false, nil
+}
+
+// checkAdoptable verifies that slice id has the topology requested by want:
+// both models are read back with TopologyFromModel and compared entry by
+// entry, capacities, images, links, facility ports and interfaces included.
+// Values the configuration leaves to the orchestrator are not compared.
+func checkAdoptable(ctx context.Context, slices services.SlicesService, id string, want *topology.Model) (orchestrator.Slice, error) {
+	sl, err := slices.Get(ctx, id)
+	if err != nil {
+		return sl, err
+	}
+	if sl.Model == "" {
+		return sl, fmt.Errorf("slice %s: the orchestrator returned no model to compare the topology with", id)
+	}
+	got, err := topology.ParseModelFormat(sl.ModelFormat, sl.Model)
+	if err != nil {
+		return sl, fmt.Errorf("slice %s: decode model: %w", id, err)
+	}
+	if diff := topologyDiff(TopologyFromModel(want), TopologyFromModel(got)); len(diff) > 0 {
+		return sl, fmt.Errorf("slice %s does not match the configured topology (- configured, + existing):\n  %s",
+			id, strings.Join(diff, "\n  "))
+	}
+	return sl, nil
+}
+
+// topologyDiff compares two topologies one entry per line. Lines only in
+// want are prefixed "- ", lines only in got "+ "; equal topologies give nil.
+func topologyDiff(want, got TopologyPlan) []string {
+	count := map[string]int{}
+	for _, l := range topologyLines(want) {
+		count[l]++
+	}
+	for _, l := range topologyLines(unsetLike(want, got)) {
+		count[l]--
+	}
+	var diff []string
+	for l, n := range count {
+		for ; n > 0; n-- {
+			diff = append(diff, "- "+l)
+		}
+		for ; n < 0; n++ {
+			diff = append(diff, "+ "+l)
+		}
+	}
+	// Order by the line itself, so a changed entry shows as adjacent -/+.
+	sort.Slice(diff, func(i, j int) bool {
+		if diff[i][2:] != diff[j][2:] {
+			return diff[i][2:] < diff[j][2:]
+		}
+		return diff[i] < diff[j]
+	})
+	return diff
+}
+
+func topologyLines(t TopologyPlan) []string {
+	var out []string
+	line := func(kind, name string, v any) {
+		b, _ := json.Marshal(v) // plain structs always encode
+		out = append(out, fmt.Sprintf("%s %s: %s", kind, name, b))
+	}
+	for _, n := range t.Nodes {
+		line("node", n.Name, n)
+	}
+	for _, l := range t.Links {
+		line("link", l.Name, l)
+	}
+	for _, fp := range t.FacilityPorts {
+		line("facility_port", fp.Name, fp)
+	}
+	for _, ns := range t.NetworkServices {
+		line("network_service", ns.Name, ns)
+	}
+	return out
+}
+
+// unsetLike returns got with the values the same-named entries of want leave
+// empty cleared, such as a VLAN or instance type the orchestrator picked.
+func unsetLike(want, got TopologyPlan) TopologyPlan {
+	nodes := map[string]NodePlan{}
+	for _, n := range want.Nodes {
+		nodes[n.Name] = n
+	}
+	ports := map[string]FacilityPortPlan{}
+	for _, fp := range want.FacilityPorts {
+		ports[fp.Name] = fp
+	}
+	services := map[string]NetworkServicePlan{}
+	for _, ns := range want.NetworkServices {
+		services[ns.Name] = ns
+	}
+
+	out := TopologyPlan{Links: got.Links}
+	for _, n := range got.Nodes {
+		if w, ok := nodes[n.Name]; ok {
+			n.ImageRef = keepIfSet(w.ImageRef, n.ImageRef)
+			n.InstanceType = keepIfSet(w.InstanceType, n.InstanceType)
+			n.Cores = keepIfSet(w.Cores, n.Cores)
+			n.RAM = keepIfSet(w.RAM, n.RAM)
+			n.Disk = keepIfSet(w.Disk, n.Disk)
+		}
+		out.Nodes = append(out.Nodes, n)
+	}
+	for _, fp := range got.FacilityPorts {
+		if w, ok := ports[fp.Name]; ok {
+			fp.VLAN = keepIfSet(w.VLAN, fp.VLAN)
+			fp.Bandwidth = keepIfSet(w.Bandwidth, fp.Bandwidth)
+			if w.Labels == nil {
+				fp.Labels = nil
+			}
+		}
+		out.FacilityPorts = append(out.FacilityPorts, fp)
+	}
+	for _, ns := range got.NetworkServices {
+		if w, ok := services[ns.Name]; ok {
+			ifcs := map[string]InterfacePlan{}
+			for _, ifc := range w.Interfaces {
+				ifcs[ifc.Node+"/"+ifc.Name] = ifc
+			}
+			var interfaces []InterfacePlan
+			for _, ifc := range ns.Interfaces {
+				if wi, ok := ifcs[ifc.Node+"/"+ifc.Name]; ok {
+					ifc.VLAN = keepIfSet(wi.VLAN, ifc.VLAN)
+					ifc.Bandwidth = keepIfSet(wi.Bandwidth, ifc.Bandwidth)
+					ifc.NICModel = keepIfSet(wi.NICModel, ifc.NICModel)
+				}
+				interfaces = append(interfaces, ifc)
+			}
+			ns.Interfaces = interfaces
+			ns.Site = keepIfSet(w.Site, ns.Site)
+		}
+		out.NetworkServices = append(out.NetworkServices, ns)
+	}
+	return out
+}
+
+// keepIfSet returns got, or the zero value when want is the zero value.
+func keepIfSet[T comparable](want, got T) T {
+	var zero T
+	if want == zero {
+		return zero
+	}
+	return got
+}
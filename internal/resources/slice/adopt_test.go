@@ -0,0 +1,93 @@
+package slice
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/topology"
+)
+
+func adoptPlan() Plan {
+	return applyDefaultsToPlan(Plan{
+		Name: "demo",
+		Topology: TopologyPlan{
+			Nodes: []NodePlan{
+				{Name: "n1", Site: "RENC", Cores: 2, RAM: 8, Disk: 10, ImageRef: "default_rocky_8"},
+				{Name: "n2", Site: "RENC"},
+			},
+			NetworkServices: []NetworkServicePlan{{
+				Name: "net", Type: "L2Bridge",
+				Interfaces: []InterfacePlan{{Node: "n1", Bandwidth: 10}, {Node: "n2"}},
+			}},
+		},
+	})
+}
+
+func TestTopologyDiff(t *testing.T) {
+	_, graph, err := BuildRequest(adoptPlan(), "g1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := TopologyFromModel(topology.Decode(graph))
+	if diff := topologyDiff(want, want); diff != nil {
+		t.Fatalf("identical topologies differ: %q", diff)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		change func(*Plan)
+		want   []string
+	}{
+		{"cores", func(p *Plan) { p.Topology.Nodes[0].Cores = 4 }, []string{`"cores":2`, `"cores":4`}},
+		{"image", func(p *Plan) { p.Topology.Nodes[1].ImageRef = "default_ubuntu_22" }, []string{"default_ubuntu_22"}},
+		{"service type", func(p *Plan) { p.Topology.NetworkServices[0].Type = "L2STS" }, []string{`"type":"L2STS"`}},
+		{"interface bandwidth", func(p *Plan) { p.Topology.NetworkServices[0].Interfaces[0].Bandwidth = 25 }, []string{`"bandwidth":25`}},
+		{"nic model", func(p *Plan) { p.Topology.NetworkServices[0].Interfaces[1].NICModel = "NIC_ConnectX_6" }, []string{"network_service net"}},
+		{"extra node", func(p *Plan) {
+			p.Topology.Nodes = append(p.Topology.Nodes, NodePlan{Name: "n3", Site: "RENC"})
+		}, []string{"+ node n3"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := adoptPlan()
+			tc.change(&p)
+			_, g, err := BuildRequest(p, "g1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			diff := strings.Join(topologyDiff(want, TopologyFromModel(topology.Decode(g))), "\n")
+			if diff == "" {
+				t.Fatal("no difference found")
+			}
+			for _, w := range tc.want {
+				if !strings.Contains(diff, w) {
+					t.Errorf("diff does not mention %s:\n%s", w, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestTopologyDiffIgnoresUnsetValues(t *testing.T) {
+	want := TopologyPlan{
+		Nodes: []NodePlan{{Name: "n1", Site: "RENC", Type: "VM", Cores: 2}},
+		NetworkServices: []NetworkServicePlan{{
+			Name: "net", Type: "L2Bridge",
+			Interfaces: []InterfacePlan{{Name: "n1-nic1-p1", Node: "n1"}},
+		}},
+	}
+	got := TopologyPlan{
+		Nodes: []NodePlan{{Name: "n1", Site: "RENC", Type: "VM", Cores: 2, InstanceType: "fabric.c2.m8.d10"}},
+		NetworkServices: []NetworkServicePlan{{
+			Name: "net", Type: "L2Bridge", Site: "RENC",
+			Interfaces: []InterfacePlan{{Name: "n1-nic1-p1", Node: "n1", VLAN: "100", NICModel: "NIC_Basic"}},
+		}},
+	}
+	if diff := topologyDiff(want, got); diff != nil {
+		t.Fatalf("values chosen by the orchestrator count as differences: %q", diff)
+	}
+
+	got.Nodes[0].Cores = 4
+	if diff := topologyDiff(want, got); len(diff) != 2 {
+		t.Fatalf("diff = %q, want the node removed and added", diff)
+	}
+}
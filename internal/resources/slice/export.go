@@ -0,0 +1,374 @@
+package slice
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/clients/orchestrator"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/topology"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TopologyFromModel rebuilds the topology configuration of a slice from its
+// model, as the reverse of BuildRequest. Values the orchestrator assigns
+// (addresses, MACs) are left out. Nodes, facility ports and services are
+// sorted by name so the result doesn't depend on the model's vertex order.
+func TopologyFromModel(m *topology.Model) TopologyPlan {
+	var t TopologyPlan
+	for _, n := range m.Nodes {
+		if n.Props["Class"] != "NetworkNode" {
+			continue
+		}
+		switch n.Props["Type"] {
+		case "Facility":
+			t.FacilityPorts = append(t.FacilityPorts, facilityPortFromModel(m, n))
+		case "Switch":
+			t.Nodes = append(t.Nodes, NodePlan{Name: n.Props["Name"], Site: n.Props["Site"], Type: "Switch"})
+		default:
+			var hints struct {
+				InstanceType string `json:"instance_type"`
+			}
+			var caps struct {
+				Core int64 `json:"core"`
+				RAM  int64 `json:"ram"`
+				Disk int64 `json:"disk"`
+			}
+			_ = n.JSONProp("CapacityHints", &hints)
+			_ = n.JSONProp("Capacities", &caps)
+			t.Nodes = append(t.Nodes, NodePlan{
+				Name:         n.Props["Name"],
+				Site:         n.Props["Site"],
+				Type:         "VM",
+				ImageRef:     n.Props["ImageRef"],
+				InstanceType: hints.InstanceType,
+				Cores:        caps.Core,
+				RAM:          caps.RAM,
+				Disk:         caps.Disk,
+			})
+		}
+	}
+
+	owner := func(id string) (topology.ModelNode, bool) {
+		n, ok := m.Node(id)
+		for ok && n.Props["Class"] != "NetworkNode" {
+			n, ok = m.Parent(n.ID)
+		}
+		return n, ok
+	}
+	for _, e := range m.Edges {
+		if e.Props["Class"] != "Link" {
+			continue
+		}
+		src, okSrc := owner(e.Source)
+		tgt, okTgt := owner(e.Target)
+		if okSrc && okTgt {
+			t.Links = append(t.Links, LinkPlan{Name: e.Props["Name"], Source: src.Props["Name"], Target: tgt.Props["Name"]})
+		}
+	}
+
+	for _, n := range m.Nodes {
+		if n.Props["Class"] != "NetworkService" {
+			continue
+		}
+		ns := NetworkServicePlan{
+			Name:            n.Props["Name"],
+			Type:            n.Props["Type"],
+			MirrorPort:      n.Props["MirrorPort"],
+			MirrorDirection: n.Props["MirrorDirection"],
+		}
+		if ns.Type == "PortMirror" {
+			ns.Site = n.Props["Site"] // otherwise derived from the interfaces
+		}
+		for _, e := range m.Edges {
+			if e.Source != n.ID || e.Props["Class"] != "connects" {
+				continue
+			}
+			cp, ok := m.Node(e.Target)
+			node, okOwner := owner(e.Target)
+			if !ok || !okOwner {
+				continue
+			}
+			ifc := InterfacePlan{Node: node.Props["Name"]}
+			if node.Props["Type"] != "Facility" { // facility port interfaces are the port's own
+				var labels struct {
+					VLAN string `json:"vlan"`
+				}
+				var caps struct {
+					BW int64 `json:"bw"`
+				}
+				_ = cp.JSONProp("Labels", &labels)
+				_ = cp.JSONProp("Capacities", &caps)
+				ifc.Name, ifc.VLAN, ifc.Bandwidth = cp.Props["Name"], labels.VLAN, caps.BW
+				if nic, ok := m.Parent(cp.ID); ok && nic.Props["Class"] == "Component" {
+					ifc.NICModel = topology.NICModel(nic)
+				}
+			}
+			ns.Interfaces = append(ns.Interfaces, ifc)
+		}
+		sort.SliceStable(ns.Interfaces, func(i, j int) bool { return ns.Interfaces[i].Name < ns.Interfaces[j].Name })
+		t.NetworkServices = append(t.NetworkServices, ns)
+	}
+
+	sort.Slice(t.Nodes, func(i, j int) bool { return t.Nodes[i].Name < t.Nodes[j].Name })
+	sort.Slice(t.Links, func(i, j int) bool { return t.Links[i].Name < t.Links[j].Name })
+	sort.Slice(t.FacilityPorts, func(i, j int) bool { return t.FacilityPorts[i].Name < t.FacilityPorts[j].Name })
+	sort.Slice(t.NetworkServices, func(i, j int) bool { return t.NetworkServices[i].Name < t.NetworkServices[j].Name })
+	return t
+}
+
+func facilityPortFromModel(m *topology.Model, n topology.ModelNode) FacilityPortPlan {
+	fp := FacilityPortPlan{Name: n.Props["Name"], Site: n.Props["Site"]}
+	for _, c := range m.Children(n.ID) {
+		if c.Props["Class"] != "ConnectionPoint" {
+			continue
+		}
+		var labels map[string]string
+		var caps struct {
+			BW int64 `json:"bw"`
+		}
+		_ = c.JSONProp("Labels", &labels)
+		_ = c.JSONProp("Capacities", &caps)
+		fp.VLAN, fp.Bandwidth = labels["vlan"], caps.BW
+		delete(labels, "vlan")
+		if len(labels) > 0 {
+			fp.Labels = labels
+		}
+		break
+	}
+	return fp
+}
+
+// ExportHCL renders a fabric_slice resource named resourceName that manages
+// sl with topology t. With withImport, an import block adopting sl precedes
+// it.
+func ExportHCL(resourceName string, sl orchestrator.Slice, t TopologyPlan, withImport bool) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Slice %s (%s), state %s\n", sl.Name, sl.ID, sl.State)
+	if withImport {
+		fmt.Fprintf(&b, "import %s\n\n", hclObject([]hclAttr{
+			{"to", "fabric_slice." + resourceName},
+			{"id", hclString(sl.ID)},
+		}, 0))
+	}
+
+	attrs := []hclAttr{{"name", hclString(sl.Name)}}
+	if sl.ProjectID != "" {
+		attrs = append(attrs, hclAttr{"project_id", hclString(sl.ProjectID)})
+	}
+	attrs = append(attrs, hclAttr{"topology", hclTopology(t, 1)})
+	fmt.Fprintf(&b, "resource \"fabric_slice\" %s %s\n", hclString(resourceName), hclObject(attrs, 0))
+	return b.String()
+}
+
+func hclTopology(t TopologyPlan, depth int) string {
+	var attrs []hclAttr
+
+	nodes := make([]hclAttr, 0, len(t.Nodes))
+	for _, n := range t.Nodes {
+		a := []hclAttr{{"site", hclString(n.Site)}}
+		if n.Type == "Switch" {
+			a = append(a, hclAttr{"type", hclString(n.Type)})
+		} else {
+			a = appendString(a, "image_ref", n.ImageRef)
+			a = appendString(a, "instance_type", n.InstanceType)
+			a = appendInt(a, "cores", n.Cores)
+			a = appendInt(a, "ram", n.RAM)
+			a = appendInt(a, "disk", n.Disk)
+		}
+		nodes = append(nodes, hclAttr{hclKey(n.Name), hclObject(a, depth+2)})
+	}
+	attrs = append(attrs, hclAttr{"nodes", hclObject(nodes, depth+1)})
+
+	if len(t.Links) > 0 {
+		links := make([]hclAttr, 0, len(t.Links))
+		for _, l := range t.Links {
+			links = append(links, hclAttr{hclKey(l.Name), hclObject([]hclAttr{
+				{"source", hclString(l.Source)},
+				{"target", hclString(l.Target)},
+			}, depth+2)})
+		}
+		attrs = append(attrs, hclAttr{"links", hclObject(links, depth+1)})
+	}
+
+	if len(t.FacilityPorts) > 0 {
+		var fps []string
+		for _, fp := range t.FacilityPorts {
+			a := []hclAttr{{"name", hclString(fp.Name)}, {"site", hclString(fp.Site)}}
+			a = appendString(a, "vlan", fp.VLAN)
+			a = appendInt(a, "bandwidth", fp.Bandwidth)
+			if len(fp.Labels) > 0 {
+				var labels []hclAttr
+				for _, k := range sortedKeys(fp.Labels) {
+					labels = append(labels, hclAttr{hclKey(k), hclString(fp.Labels[k])})
+				}
+				a = append(a, hclAttr{"labels", hclObject(labels, depth+3)})
+			}
+			fps = append(fps, hclObject(a, depth+2))
+		}
+		attrs = append(attrs, hclAttr{"facility_ports", hclList(fps, depth+1)})
+	}
+
+	if len(t.NetworkServices) > 0 {
+		var svcs []string
+		for _, ns := range t.NetworkServices {
+			a := []hclAttr{{"name", hclString(ns.Name)}, {"type", hclString(ns.Type)}}
+			a = appendString(a, "site", ns.Site)
+			a = appendString(a, "mirror_port", ns.MirrorPort)
+			a = appendString(a, "mirror_direction", ns.MirrorDirection)
+			var ifcs []string
+			for _, ifc := range ns.Interfaces {
+				ia := []hclAttr{{"node", hclString(ifc.Node)}}
+				ia = appendString(ia, "name", ifc.Name)
+				ia = appendString(ia, "nic_model", ifc.NICModel)
+				ia = appendString(ia, "vlan", ifc.VLAN)
+				ia = appendInt(ia, "bandwidth", ifc.Bandwidth)
+				ifcs = append(ifcs, hclObject(ia, depth+4))
+			}
+			a = append(a, hclAttr{"interfaces", hclList(ifcs, depth+3)})
+			svcs = append(svcs, hclObject(a, depth+2))
+		}
+		attrs = append(attrs, hclAttr{"network_services", hclList(svcs, depth+1)})
+	}
+	return hclObject(attrs, depth)
+}
+
+// ---------- HCL writing ----------
+
+type hclAttr struct{ key, value string }
+
+func appendString(a []hclAttr, key, v string) []hclAttr {
+	if v == "" {
+		return a
+	}
+	return append(a, hclAttr{key, hclString(v)})
+}
+
+func appendInt(a []hclAttr, key string, v int64) []hclAttr {
+	if v == 0 {
+		return a
+	}
+	return append(a, hclAttr{key, fmt.Sprint(v)})
+}
+
+// hclObject renders attrs as an object whose closing brace is indented for
+// depth. The "=" of consecutive single-line attributes line up, as terraform
+// fmt would have them.
+func hclObject(attrs []hclAttr, depth int) string {
+	if len(attrs) == 0 {
+		return "{}"
+	}
+	pad := strings.Repeat("  ", depth+1)
+	var b strings.Builder
+	b.WriteString("{\n")
+	for i := 0; i < len(attrs); {
+		if strings.Contains(attrs[i].value, "\n") {
+			fmt.Fprintf(&b, "%s%s = %s\n", pad, attrs[i].key, attrs[i].value)
+			i++
+			continue
+		}
+		j, width := i, 0
+		for ; j < len(attrs) && !strings.Contains(attrs[j].value, "\n"); j++ {
+			width = max(width, len(attrs[j].key))
+		}
+		for ; i < j; i++ {
+			fmt.Fprintf(&b, "%s%-*s = %s\n", pad, width, attrs[i].key, attrs[i].value)
+		}
+	}
+	b.WriteString(strings.Repeat("  ", depth) + "}")
+	return b.String()
+}
+
+// hclList renders already rendered items as a list at depth.
+func hclList(items []string, depth int) string {
+	if len(items) == 0 {
+		return "[]"
+	}
+	pad := strings.Repeat("  ", depth+1)
+	var b strings.Builder
+	b.WriteString("[\n")
+	for _, it := range items {
+		fmt.Fprintf(&b, "%s%s,\n", pad, it)
+	}
+	b.WriteString(strings.Repeat("  ", depth) + "]")
+	return b.String()
+}
+
+var hclIdent = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// hclKey returns k as an object key, quoted unless it is an identifier.
+func hclKey(k string) string {
+	if hclIdent.MatchString(k) {
+		return k
+	}
+	return hclString(k)
+}
+
+// hclString quotes s as an HCL string literal, escaping template sequences
+// so "${" and "%{" stay literal.
+func hclString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, `\u%04x`, r)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			b.WriteRune(r)
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// ResourceName turns a slice name into a Terraform resource name.
+func ResourceName(sliceName string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(sliceName) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	name := strings.Trim(b.String(), "_")
+	if name == "" || name[0] >= '0' && name[0] <= '9' {
+		name = "slice_" + name
+	}
+	return name
+}
+
+// importTopology fills in the topology of an imported slice from its model,
+// so generated configuration and plans see the slice as it is.
+func importTopology(tf *TFPlan, sl orchestrator.Slice) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if sl.Model == "" {
+		diags.AddWarning("Imported slice has no topology",
+			fmt.Sprintf("Slice %s was read without its model, so topology is left empty.", sl.ID))
+		return diags
+	}
+	m, err := topology.ParseModelFormat(sl.ModelFormat, sl.Model)
+	if err != nil {
+		diags.AddWarning("Could not decode slice model", err.Error())
+		return diags
+	}
+	t := TopologyFromModel(m)
+	tf.Topology = topologyToTF(t, nil)
+	tf.TopologyDOT = types.StringValue(topology.RenderDOT(m))
+	return diags
+}
@@ -0,0 +1,104 @@
+package slice
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/topology"
+)
+
+func TestTopologyFromModelRoundTrip(t *testing.T) {
+	in := Plan{Topology: TopologyPlan{
+		Nodes: []NodePlan{
+			{Name: "n1", Site: "RENC", ImageRef: "default_rocky_8", InstanceType: "fabric.c4.m16.d100", Cores: 4, RAM: 16, Disk: 100},
+			{Name: "n2", Site: "RENC"},
+			{Name: "sw1", Site: "RENC", Type: "Switch"},
+		},
+		Links: []LinkPlan{{Name: "l1", Source: "n1", Target: "sw1"}},
+		FacilityPorts: []FacilityPortPlan{{
+			Name: "fp1", Site: "RENC", VLAN: "100", Bandwidth: 25,
+			Labels: map[string]string{"local_name": "HundredGigE0/0/0/1"},
+		}},
+		NetworkServices: []NetworkServicePlan{
+			{
+				Name: "mirror", Type: "PortMirror", Site: "RENC",
+				MirrorPort: "HundredGigE0/0/0/15", MirrorDirection: "rx",
+				Interfaces: []InterfacePlan{{Node: "n2", NICModel: "NIC_ConnectX_6"}},
+			},
+			{
+				Name: "net", Type: "L2Bridge",
+				Interfaces: []InterfacePlan{
+					{Node: "n1", VLAN: "200", Bandwidth: 10},
+					{Node: "n2", NICModel: "NIC_ConnectX_5"},
+					{Node: "sw1"},
+					{Node: "fp1"},
+				},
+			},
+		},
+	}}
+
+	want, graph, err := BuildRequest(in, "g1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	xml, err := topology.Marshal(graph)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := topology.ParseModel(xml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := TopologyFromModel(m)
+
+	// A facility port's interface is the port's own, so only the port name
+	// comes back; interfaces come back sorted by name.
+	for _, ns := range want.Topology.NetworkServices {
+		for j, ifc := range ns.Interfaces {
+			if ifc.Node == "fp1" {
+				ns.Interfaces[j] = InterfacePlan{Node: "fp1"}
+			}
+		}
+		sort.SliceStable(ns.Interfaces, func(i, j int) bool { return ns.Interfaces[i].Name < ns.Interfaces[j].Name })
+	}
+	if !reflect.DeepEqual(got, want.Topology) {
+		t.Errorf("round trip changed the topology:\n got %+v\nwant %+v", got, want.Topology)
+	}
+}
+
+func TestHCLString(t *testing.T) {
+	for _, tc := range []struct{ in, want string }{
+		{"plain", `"plain"`},
+		{"${var.x}", `"$${var.x}"`},
+		{"%{ if x }", `"%%{ if x }"`},
+		{"$5 and 100%", `"$5 and 100%"`},
+		{"$${x}", `"$$${x}"`},
+		{`say "hi"`, `"say \"hi\""`},
+		{`C:\slices`, `"C:\\slices"`},
+		{"a\nb\tc\r", `"a\nb\tc\r"`},
+		{"bell\x07 del\x7f", `"bell\u0007 del\u007f"`},
+		{"naïve", `"naïve"`},
+	} {
+		if got := hclString(tc.in); got != tc.want {
+			t.Errorf("hclString(%q) = %s, want %s", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestResourceName(t *testing.T) {
+	for _, tc := range []struct{ in, want string }{
+		{"demo", "demo"},
+		{"My-Slice.v2", "my_slice_v2"},
+		{"__edge__", "edge"},
+		{"2024-experiment", "slice_2024_experiment"},
+		{"-9lives", "slice_9lives"},
+		{"!!!", "slice_"},
+		{"", "slice_"},
+		{"日本", "slice_"},
+	} {
+		if got := ResourceName(tc.in); got != tc.want {
+			t.Errorf("ResourceName(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
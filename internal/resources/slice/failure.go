@@ -0,0 +1,92 @@
+package slice
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/services"
+	rframework "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	onFailureKeep   = "keep"
+	onFailureTaint  = "taint"
+	onFailureDelete = "delete"
+)
+
+func onFailureFrom(v types.String) (string, error) {
+	switch s := toString(v); s {
+	case "":
+		return onFailureKeep, nil
+	case onFailureKeep, onFailureTaint, onFailureDelete:
+		return s, nil
+	default:
+		return "", fmt.Errorf("on_failure must be %q, %q or %q, got %q", onFailureKeep, onFailureTaint, onFailureDelete, s)
+	}
+}
+
+// createFailed finishes a Create whose slice exists but did not become
+// healthy. With on_failure "delete" the slice is deleted and left out of
+// state; otherwise it is recorded, and the error taints it.
+func createFailed(ctx context.Context, slices services.SlicesService, policy string, tf *TFPlan, resp *rframework.CreateResponse, summary, detail string) {
+	id := toString(tf.ID)
+	// The create context may be what ran out; cleanup must still happen.
+	ctx = context.WithoutCancel(ctx)
+	detail += sliverErrors(ctx, slices, id)
+
+	if policy == onFailureDelete {
+		timeout, _ := deleteTimeout(*tf) // validated before creating
+		dctx, cancel := context.WithTimeout(ctx, timeout)
+		err := slices.Delete(dctx, id)
+		cancel()
+		if err == nil {
+			resp.Diagnostics.AddError(summary, detail+"\n\nThe slice was deleted (on_failure = \"delete\").")
+			return
+		}
+		detail += fmt.Sprintf("\n\nDeleting the slice (on_failure = \"delete\") failed, so it was kept in state: %s", err)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tf)...)
+	resp.Diagnostics.AddError(summary, detail)
+}
+
+// sliverErrors lists the failed slivers of slice id and their notices, as a
+// paragraph to append to an error. It is empty if none are reported.
+func sliverErrors(ctx context.Context, slices services.SlicesService, id string) string {
+	slivers, err := slices.Slivers(ctx, id)
+	if err != nil {
+		return fmt.Sprintf("\n\nListing the slice's slivers failed: %s", err)
+	}
+	var b strings.Builder
+	for _, sv := range slivers {
+		if sv.State != "Failed" {
+			continue
+		}
+		name := sv.Name
+		if name == "" {
+			name = sv.ID
+		}
+		fmt.Fprintf(&b, "\n  - %s (%s): %s", name, sv.Type, strings.TrimSpace(sv.Notice))
+	}
+	if b.Len() == 0 {
+		return ""
+	}
+	return "\n\nFailed slivers:" + b.String()
+}
+
+// closingNamesake returns the ID of a closing slice named name, if any. The
+// orchestrator keeps a name taken until its slice is Dead, so creating a
+// slice right after destroying its predecessor can fail on that.
+func closingNamesake(ctx context.Context, slices services.SlicesService, name string) string {
+	found, err := slices.List(ctx, name, []string{"Closing"})
+	if err != nil {
+		return ""
+	}
+	for _, sl := range found {
+		if sl.Name == name {
+			return sl.ID
+		}
+	}
+	return ""
+}
@@ -0,0 +1,139 @@
+package slice
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/topology"
+)
+
+// ParsePlanJSON reads a fabric_slice configuration from JSON, either the
+// resource's attributes ({"name": ..., "topology": {...}}) or a topology on
+// its own. Nodes and links may be objects keyed by name, as written in HCL
+// or by jsonencode and `terraform show -json`, or lists of objects with a
+// "name".
+func ParsePlanJSON(data []byte) (Plan, error) {
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(data, &top); err != nil {
+		return Plan{}, err
+	}
+
+	var in struct {
+		Name         string          `json:"name"`
+		LeaseEndTime string          `json:"lease_end_time"`
+		SSHKeys      []string        `json:"ssh_keys"`
+		ProjectID    string          `json:"project_id"`
+		Topology     json.RawMessage `json:"topology"`
+	}
+	raw := data
+	if _, ok := top["topology"]; ok {
+		if err := json.Unmarshal(data, &in); err != nil {
+			return Plan{}, err
+		}
+		raw = in.Topology
+	} else if _, ok := top["nodes"]; !ok {
+		return Plan{}, errors.New(`expected a fabric_slice configuration with "topology", or a topology with "nodes"`)
+	}
+
+	var topo struct {
+		Nodes           json.RawMessage      `json:"nodes"`
+		Links           json.RawMessage      `json:"links"`
+		FacilityPorts   []FacilityPortPlan   `json:"facility_ports"`
+		NetworkServices []NetworkServicePlan `json:"network_services"`
+	}
+	if err := json.Unmarshal(raw, &topo); err != nil {
+		return Plan{}, fmt.Errorf("topology: %w", err)
+	}
+	p := Plan{
+		Name:         in.Name,
+		LeaseEndTime: in.LeaseEndTime,
+		SSHKeys:      in.SSHKeys,
+		ProjectID:    in.ProjectID,
+		Topology: TopologyPlan{
+			FacilityPorts:   topo.FacilityPorts,
+			NetworkServices: topo.NetworkServices,
+		},
+	}
+	var err error
+	if p.Topology.Nodes, err = namedJSON(topo.Nodes, func(n *NodePlan) *string { return &n.Name }); err != nil {
+		return p, fmt.Errorf("topology.nodes: %w", err)
+	}
+	if p.Topology.Links, err = namedJSON(topo.Links, func(l *LinkPlan) *string { return &l.Name }); err != nil {
+		return p, fmt.Errorf("topology.links: %w", err)
+	}
+	return p, nil
+}
+
+// CheckRequest validates p as Terraform and BuildRequest together would,
+// reporting every problem found, and returns the GraphML request for it.
+func CheckRequest(p Plan, graphID string) (topology.GraphML, error) {
+	required := validateRequired(p)
+	_, graph, err := BuildRequest(p, graphID)
+	return graph, errors.Join(required, err)
+}
+
+// namedJSON decodes a list of T, or an object of T keyed by name. Keyed
+// entries get their key as name and are returned sorted by it, as
+// FromTFPlan orders them.
+func namedJSON[T any](raw json.RawMessage, name func(*T) *string) ([]T, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil, nil
+	}
+	if raw[0] == '[' {
+		var out []T
+		err := json.Unmarshal(raw, &out)
+		return out, err
+	}
+	var byName map[string]T
+	if err := json.Unmarshal(raw, &byName); err != nil {
+		return nil, err
+	}
+	out := make([]T, 0, len(byName))
+	for _, k := range sortedKeys(byName) {
+		v := byName[k]
+		*name(&v) = k
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+// validateRequired checks the attributes the schema marks required, which
+// Terraform enforces before the provider sees a plan.
+func validateRequired(p Plan) error {
+	var errs []error
+	if len(p.Topology.Nodes) == 0 {
+		errs = append(errs, errors.New("topology.nodes: at least one node is required"))
+	}
+	for i, n := range p.Topology.Nodes {
+		if n.Name == "" {
+			errs = append(errs, fmt.Errorf("node %d: name is required", i))
+		}
+		if n.Site == "" {
+			errs = append(errs, fmt.Errorf("node %q: site is required", n.Name))
+		}
+	}
+	for _, l := range p.Topology.Links {
+		if l.Source == "" || l.Target == "" {
+			errs = append(errs, fmt.Errorf("link %q: source and target are required", l.Name))
+		}
+	}
+	for i, fp := range p.Topology.FacilityPorts {
+		if fp.Name == "" || fp.Site == "" {
+			errs = append(errs, fmt.Errorf("facility port %d (%q): name and site are required", i, fp.Name))
+		}
+	}
+	for i, ns := range p.Topology.NetworkServices {
+		if ns.Name == "" {
+			errs = append(errs, fmt.Errorf("network service %d: name is required", i))
+		}
+		for j, ifc := range ns.Interfaces {
+			if ifc.Node == "" {
+				errs = append(errs, fmt.Errorf("network service %q: interface %d: node is required", ns.Name, j))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,87 @@
+package slice
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePlanJSONShapes(t *testing.T) {
+	want := TopologyPlan{
+		Nodes: []NodePlan{
+			{Name: "n1", Site: "RENC", Cores: 2},
+			{Name: "n2", Site: "UCSD"},
+		},
+		Links: []LinkPlan{{Name: "l1", Source: "n1", Target: "n2"}},
+	}
+	const (
+		keyedNodes = `{"n2": {"site": "UCSD"}, "n1": {"site": "RENC", "cores": 2}}`
+		listNodes  = `[{"name": "n1", "site": "RENC", "cores": 2}, {"name": "n2", "site": "UCSD"}]`
+		keyedLinks = `{"l1": {"source": "n1", "target": "n2"}}`
+		listLinks  = `[{"name": "l1", "source": "n1", "target": "n2"}]`
+	)
+	topo := func(nodes, links string) string {
+		return `{"nodes": ` + nodes + `, "links": ` + links + `}`
+	}
+
+	for _, tc := range []struct {
+		name, json string
+		slice      string
+	}{
+		{"bare keyed", topo(keyedNodes, keyedLinks), ""},
+		{"bare lists", topo(listNodes, listLinks), ""},
+		{"bare mixed", topo(keyedNodes, listLinks), ""},
+		{"resource keyed", `{"name": "demo", "topology": ` + topo(keyedNodes, keyedLinks) + `}`, "demo"},
+		{"resource lists", `{"name": "demo", "topology": ` + topo(listNodes, listLinks) + `}`, "demo"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := ParsePlanJSON([]byte(tc.json))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.Name != tc.slice {
+				t.Errorf("name = %q, want %q", p.Name, tc.slice)
+			}
+			if !reflect.DeepEqual(p.Topology, want) {
+				t.Errorf("topology = %+v, want %+v", p.Topology, want)
+			}
+		})
+	}
+}
+
+func TestParsePlanJSONMissingNodes(t *testing.T) {
+	if _, err := ParsePlanJSON([]byte(`{"links": {}}`)); err == nil || !strings.Contains(err.Error(), `"nodes"`) {
+		t.Errorf("topology without nodes: error = %v", err)
+	}
+
+	// A resource whose topology has no nodes parses, but fails the check.
+	p, err := ParsePlanJSON([]byte(`{"name": "demo", "topology": {}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CheckRequest(p, "g1"); err == nil || !strings.Contains(err.Error(), "at least one node is required") {
+		t.Errorf("CheckRequest error = %v", err)
+	}
+}
+
+func TestCheckRequestReportsAllErrors(t *testing.T) {
+	p, err := ParsePlanJSON([]byte(`{
+		"nodes": {"n1": {}, "n2": {"site": "RENC"}},
+		"links": {"l1": {"source": "n2", "target": "n9"}}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = CheckRequest(p, "g1")
+	if err == nil {
+		t.Fatal("invalid topology passed the check")
+	}
+	for _, want := range []string{
+		`node "n1": site is required`,                           // Terraform's required attributes
+		`link "l1": unknown node, switch or facility port "n9"`, // BuildRequest's cross-references
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not report %s:\n%v", want, err)
+		}
+	}
+}
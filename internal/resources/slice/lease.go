@@ -0,0 +1,117 @@
+package slice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/clients/orchestrator"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/services"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultRenewBefore   = 24 * time.Hour
+	defaultRenewDuration = 7 * 24 * time.Hour
+
+	// leaseWarning is how close to expiry a slice without auto_renew gets
+	// before refreshes warn about it.
+	leaseWarning = 24 * time.Hour
+)
+
+// leasePolicy is auto_renew with defaults applied. A zero policy never
+// renews; maxLease 0 means no limit.
+type leasePolicy struct {
+	enabled  bool
+	before   time.Duration
+	duration time.Duration
+	maxLease time.Duration
+}
+
+func leasePolicyFrom(a *TFAutoRenew) (leasePolicy, error) {
+	if a == nil {
+		return leasePolicy{}, nil
+	}
+	p := leasePolicy{enabled: true, before: defaultRenewBefore, duration: defaultRenewDuration}
+	parse := func(attr string, v types.String, dst *time.Duration) error {
+		if s := toString(v); s != "" {
+			d, err := utils.ParseLeaseDuration(s)
+			if err != nil {
+				return fmt.Errorf("auto_renew.%s: %w", attr, err)
+			}
+			*dst = d
+		}
+		return nil
+	}
+	if err := parse("renew_before", a.RenewBefore, &p.before); err != nil {
+		return p, err
+	}
+	if err := parse("renew_duration", a.RenewDuration, &p.duration); err != nil {
+		return p, err
+	}
+	if err := parse("max_lease", a.MaxLease, &p.maxLease); err != nil {
+		return p, err
+	}
+	if p.duration <= p.before {
+		return p, fmt.Errorf("auto_renew.renew_duration (%s) must be longer than renew_before (%s), or every refresh would renew", p.duration, p.before)
+	}
+	return p, nil
+}
+
+// refreshLease records the lease times reported for sl in tf and, when renew
+// is set and auto_renew asks for it, renews a lease that is about to run
+// out. Leases close to expiry that are not renewed produce a warning.
+func refreshLease(ctx context.Context, slices services.SlicesService, tf *TFPlan, sl orchestrator.Slice, renew bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	tf.LeaseStartTime = optionalString(sl.LeaseStartTime)
+	tf.LeaseRemainingSeconds = types.Int64Null()
+	if tf.LeaseEndTime.IsNull() || tf.LeaseEndTime.IsUnknown() {
+		tf.LeaseEndTime = optionalString(sl.LeaseEndTime) // imported
+	}
+
+	end, err := utils.ParseLease(sl.LeaseEndTime)
+	if err != nil || services.IsGone(sl.State) {
+		return diags
+	}
+	policy, err := leasePolicyFrom(tf.AutoRenew)
+	if err != nil {
+		diags.AddError("Invalid auto_renew", err.Error())
+		return diags
+	}
+
+	now := time.Now()
+	remaining := end.Sub(now)
+	if renew && policy.enabled && remaining < policy.before {
+		target := now.Add(policy.duration)
+		if start, err := utils.ParseLease(sl.LeaseStartTime); err == nil && policy.maxLease > 0 {
+			if limit := start.Add(policy.maxLease); target.After(limit) {
+				target = limit
+			}
+		}
+		if !target.After(end) {
+			diags.AddWarning("Slice lease cannot be renewed further",
+				fmt.Sprintf("Slice %s reached auto_renew.max_lease; its lease ends at %s (in %s).",
+					sl.ID, sl.LeaseEndTime, remaining.Round(time.Minute)))
+		} else if lease, err := slices.Renew(ctx, sl.ID, target.UTC().Format(time.RFC3339)); err != nil {
+			diags.AddWarning("Slice lease renewal failed",
+				fmt.Sprintf("Slice %s lease ends at %s (in %s): %s", sl.ID, sl.LeaseEndTime, remaining.Round(time.Minute), err))
+		} else {
+			tflog.Info(ctx, "Renewed slice lease", map[string]interface{}{"slice_id": sl.ID, "lease_end_time": lease})
+			tf.LeaseEndTime = types.StringValue(lease)
+			remaining = target.Sub(now)
+		}
+	} else if !policy.enabled && remaining < leaseWarning && remaining > 0 {
+		diags.AddWarning("Slice lease expires soon",
+			fmt.Sprintf("Slice %s lease ends at %s (in %s). Renew it by raising lease_end_time, or set auto_renew.",
+				sl.ID, sl.LeaseEndTime, remaining.Round(time.Minute)))
+	}
+
+	if remaining < 0 {
+		remaining = 0
+	}
+	tf.LeaseRemainingSeconds = types.Int64Value(int64(remaining / time.Second))
+	return diags
+}
@@ -6,6 +6,16 @@ import (
 	"github.com/csc478-wcu/terraform-provider-fabric/internal/topology"
 )
 
+// BuildRequest applies provider defaults to p, validates it and builds the
+// GraphML request that would be submitted for it.
+func BuildRequest(p Plan, graphID string) (Plan, topology.GraphML, error) {
+	pNorm := applyDefaultsToPlan(p)
+	if err := validatePlan(pNorm); err != nil {
+		return pNorm, topology.GraphML{}, err
+	}
+	return pNorm, planToGraphML(pNorm, graphID), nil
+}
+
 func planToGraphML(p Plan, graphID string) topology.GraphML {
 	nodes := make([]topology.NodeConfig, 0, len(p.Topology.Nodes))
 	for i, n := range p.Topology.Nodes {
@@ -13,24 +23,24 @@ func planToGraphML(p Plan, graphID string) topology.GraphML {
 		if nodeType == "" {
 			nodeType = "VM"
 		}
-		image := n.ImageRef
-		if image == "" {
-			image = "default_rocky_8,qcow2"
-		}
-		inst := n.InstanceType
-		if inst == "" {
-			inst = "fabric.c2.m2.d10"
-		}
-
+		image, inst := n.ImageRef, n.InstanceType
 		cores, ram, disk := n.Cores, n.RAM, n.Disk
-		if cores == 0 {
-			cores = 2
-		}
-		if ram == 0 {
-			ram = 2
-		}
-		if disk == 0 {
-			disk = 10
+		if nodeType != "Switch" { // switches have no VM sizing
+			if image == "" {
+				image = "default_rocky_8,qcow2"
+			}
+			if inst == "" {
+				inst = "fabric.c2.m2.d10"
+			}
+			if cores == 0 {
+				cores = 2
+			}
+			if ram == 0 {
+				ram = 2
+			}
+			if disk == 0 {
+				disk = 10
+			}
 		}
 
 		name := n.Name
@@ -59,9 +69,75 @@ func planToGraphML(p Plan, graphID string) topology.GraphML {
 		})
 	}
 
+	facilityPorts := make([]topology.FacilityPortConfig, 0, len(p.Topology.FacilityPorts))
+	for _, fp := range p.Topology.FacilityPorts {
+		bw := fp.Bandwidth
+		if bw == 0 {
+			bw = 10
+		}
+		facilityPorts = append(facilityPorts, topology.FacilityPortConfig{
+			Name:      fp.Name,
+			Site:      fp.Site,
+			VLAN:      fp.VLAN,
+			Bandwidth: bw,
+			Labels:    fp.Labels,
+		})
+	}
+
+	sites := make(map[string]string, len(nodes)+len(facilityPorts))
+	for _, n := range nodes {
+		sites[n.Name] = n.Site
+	}
+	for _, fp := range facilityPorts {
+		sites[fp.Name] = fp.Site
+	}
+
+	services := make([]topology.NetworkServiceConfig, 0, len(p.Topology.NetworkServices))
+	for _, ns := range p.Topology.NetworkServices {
+		site := ns.Site
+		if site == "" {
+			site = commonSite(ns.Interfaces, sites)
+		}
+		svc := topology.NetworkServiceConfig{
+			Name:            ns.Name,
+			Type:            ns.Type,
+			Site:            site,
+			MirrorPort:      ns.MirrorPort,
+			MirrorDirection: ns.MirrorDirection,
+		}
+		for _, ifc := range ns.Interfaces {
+			svc.Interfaces = append(svc.Interfaces, topology.InterfaceConfig{
+				Name:      ifc.Name,
+				Node:      ifc.Node,
+				VLAN:      ifc.VLAN,
+				Bandwidth: ifc.Bandwidth,
+				MAC:       ifc.MAC,
+				IPAddr:    ifc.IPAddr,
+				NICModel:  ifc.NICModel,
+			})
+		}
+		services = append(services, svc)
+	}
+
 	return topology.CreateCustomTopology(topology.TopologyConfig{
-		GraphID: graphID,
-		Nodes:   nodes,
-		Links:   links,
+		GraphID:         graphID,
+		Nodes:           nodes,
+		Links:           links,
+		FacilityPorts:   facilityPorts,
+		NetworkServices: services,
 	})
 }
+
+// commonSite returns the site shared by every interface of a service, or ""
+// when the service spans sites (the orchestrator then picks the path).
+func commonSite(ifcs []InterfacePlan, sites map[string]string) string {
+	site := ""
+	for _, ifc := range ifcs {
+		s := sites[ifc.Node]
+		if site != "" && s != site {
+			return ""
+		}
+		site = s
+	}
+	return site
+}
@@ -1,6 +1,10 @@
 package slice
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
 
 // ---------- Framework-facing (plan/state) model ----------
 
@@ -12,15 +16,40 @@ type TFPlan struct {
 	Topology     *TFTopology  `tfsdk:"topology"`
 	State        types.String `tfsdk:"state"`
 	SliverCount  types.Int64  `tfsdk:"sliver_count"`
+	GraphModel   types.String `tfsdk:"graph_model"`
+	TopologyDOT  types.String `tfsdk:"topology_dot"`
+	SSHConfig    types.String `tfsdk:"ssh_config"`
+	ProjectID    types.String `tfsdk:"project_id"`
+	Endpoint     types.String `tfsdk:"endpoint"`
+
+	LeaseStartTime        types.String `tfsdk:"lease_start_time"`
+	LeaseRemainingSeconds types.Int64  `tfsdk:"lease_remaining_seconds"`
+	AutoRenew             *TFAutoRenew `tfsdk:"auto_renew"`
+	OnFailure             types.String `tfsdk:"on_failure"`
+	Timeouts              *TFTimeouts  `tfsdk:"timeouts"`
+	AdoptExisting         types.Bool   `tfsdk:"adopt_existing"`
+}
+
+type TFTimeouts struct {
+	Delete types.String `tfsdk:"delete"`
+}
+
+type TFAutoRenew struct {
+	RenewBefore   types.String `tfsdk:"renew_before"`
+	RenewDuration types.String `tfsdk:"renew_duration"`
+	MaxLease      types.String `tfsdk:"max_lease"`
 }
 
+// TFTopology keys nodes and links by name, so adding or removing one only
+// changes that entry in the plan.
 type TFTopology struct {
-	Nodes []TFNode `tfsdk:"nodes"`
-	Links []TFLink `tfsdk:"links"`
+	Nodes           map[string]TFNode  `tfsdk:"nodes"`
+	Links           map[string]TFLink  `tfsdk:"links"`
+	FacilityPorts   []TFFacilityPort   `tfsdk:"facility_ports"`
+	NetworkServices []TFNetworkService `tfsdk:"network_services"`
 }
 
 type TFNode struct {
-	Name         types.String `tfsdk:"name"`
 	Site         types.String `tfsdk:"site"`
 	Type         types.String `tfsdk:"type"`
 	ImageRef     types.String `tfsdk:"image_ref"`
@@ -28,46 +57,121 @@ type TFNode struct {
 	Cores        types.Int64  `tfsdk:"cores"`
 	RAM          types.Int64  `tfsdk:"ram"`
 	Disk         types.Int64  `tfsdk:"disk"`
+
+	ManagementIP types.String `tfsdk:"management_ip"`
+	Username     types.String `tfsdk:"username"`
+	SSHCommand   types.String `tfsdk:"ssh_command"`
+
+	PostBootScript   types.String `tfsdk:"post_boot_script"`
+	PostBootExitCode types.Int64  `tfsdk:"post_boot_exit_code"`
+	PostBootOutput   types.String `tfsdk:"post_boot_output"`
 }
 
 type TFLink struct {
-	Name   types.String `tfsdk:"name"`
 	Source types.String `tfsdk:"source"`
 	Target types.String `tfsdk:"target"`
 }
 
+type TFFacilityPort struct {
+	Name      types.String `tfsdk:"name"`
+	Site      types.String `tfsdk:"site"`
+	VLAN      types.String `tfsdk:"vlan"`
+	Bandwidth types.Int64  `tfsdk:"bandwidth"`
+	Labels    types.Map    `tfsdk:"labels"`
+}
+
+type TFNetworkService struct {
+	Name       types.String  `tfsdk:"name"`
+	Type       types.String  `tfsdk:"type"`
+	Subnet     types.String  `tfsdk:"subnet"`
+	Gateway    types.String  `tfsdk:"gateway"`
+	Interfaces []TFInterface `tfsdk:"interfaces"`
+
+	Site            types.String `tfsdk:"site"`
+	MirrorPort      types.String `tfsdk:"mirror_port"`
+	MirrorDirection types.String `tfsdk:"mirror_direction"`
+}
+
+type TFInterface struct {
+	Name      types.String `tfsdk:"name"`
+	Node      types.String `tfsdk:"node"`
+	VLAN      types.String `tfsdk:"vlan"`
+	Bandwidth types.Int64  `tfsdk:"bandwidth"`
+	MAC       types.String `tfsdk:"mac"`
+	IPAddr    types.String `tfsdk:"ip_addr"`
+	NICModel  types.String `tfsdk:"nic_model"`
+}
+
 // ---------- Domain model ----------
 
 type Plan struct {
-	ID           string       `tfsdk:"id"`
-	Name         string       `tfsdk:"name"`
-	LeaseEndTime string       `tfsdk:"lease_end_time"`
-	SSHKeys      []string     `tfsdk:"ssh_keys"`
-	Topology     TopologyPlan `tfsdk:"topology"`
-	State        string       `tfsdk:"state"`
-	SliverCount  int64        `tfsdk:"sliver_count"`
+	ID           string       `tfsdk:"id" json:"id,omitempty"`
+	Name         string       `tfsdk:"name" json:"name,omitempty"`
+	LeaseEndTime string       `tfsdk:"lease_end_time" json:"lease_end_time,omitempty"`
+	SSHKeys      []string     `tfsdk:"ssh_keys" json:"ssh_keys,omitempty"`
+	Topology     TopologyPlan `tfsdk:"topology" json:"topology,omitempty"`
+	State        string       `tfsdk:"state" json:"state,omitempty"`
+	SliverCount  int64        `tfsdk:"sliver_count" json:"sliver_count,omitempty"`
+	GraphModel   string       `tfsdk:"graph_model" json:"graph_model,omitempty"`
+	TopologyDOT  string       `tfsdk:"topology_dot" json:"topology_dot,omitempty"`
+	ProjectID    string       `tfsdk:"project_id" json:"project_id,omitempty"`
+	Endpoint     string       `tfsdk:"endpoint" json:"endpoint,omitempty"`
 }
 
 type TopologyPlan struct {
-	Nodes []NodePlan `tfsdk:"nodes"`
-	Links []LinkPlan `tfsdk:"links"`
+	Nodes           []NodePlan           `tfsdk:"nodes" json:"nodes,omitempty"`
+	Links           []LinkPlan           `tfsdk:"links" json:"links,omitempty"`
+	FacilityPorts   []FacilityPortPlan   `tfsdk:"facility_ports" json:"facility_ports,omitempty"`
+	NetworkServices []NetworkServicePlan `tfsdk:"network_services" json:"network_services,omitempty"`
 }
 
 type NodePlan struct {
-	Name         string `tfsdk:"name"`
-	Site         string `tfsdk:"site"`
-	Type         string `tfsdk:"type"`
-	ImageRef     string `tfsdk:"image_ref"`
-	InstanceType string `tfsdk:"instance_type"`
-	Cores        int64  `tfsdk:"cores"`
-	RAM          int64  `tfsdk:"ram"`
-	Disk         int64  `tfsdk:"disk"`
+	Name         string `tfsdk:"name" json:"name,omitempty"`
+	Site         string `tfsdk:"site" json:"site,omitempty"`
+	Type         string `tfsdk:"type" json:"type,omitempty"`
+	ImageRef     string `tfsdk:"image_ref" json:"image_ref,omitempty"`
+	InstanceType string `tfsdk:"instance_type" json:"instance_type,omitempty"`
+	Cores        int64  `tfsdk:"cores" json:"cores,omitempty"`
+	RAM          int64  `tfsdk:"ram" json:"ram,omitempty"`
+	Disk         int64  `tfsdk:"disk" json:"disk,omitempty"`
+
+	PostBootScript string `tfsdk:"post_boot_script" json:"post_boot_script,omitempty"`
 }
 
 type LinkPlan struct {
-	Name   string `tfsdk:"name"`
-	Source string `tfsdk:"source"`
-	Target string `tfsdk:"target"`
+	Name   string `tfsdk:"name" json:"name,omitempty"`
+	Source string `tfsdk:"source" json:"source,omitempty"`
+	Target string `tfsdk:"target" json:"target,omitempty"`
+}
+
+type FacilityPortPlan struct {
+	Name      string            `tfsdk:"name" json:"name,omitempty"`
+	Site      string            `tfsdk:"site" json:"site,omitempty"`
+	VLAN      string            `tfsdk:"vlan" json:"vlan,omitempty"`
+	Bandwidth int64             `tfsdk:"bandwidth" json:"bandwidth,omitempty"`
+	Labels    map[string]string `tfsdk:"labels" json:"labels,omitempty"`
+}
+
+type NetworkServicePlan struct {
+	Name       string          `tfsdk:"name" json:"name,omitempty"`
+	Type       string          `tfsdk:"type" json:"type,omitempty"`
+	Subnet     string          `tfsdk:"subnet" json:"subnet,omitempty"`
+	Gateway    string          `tfsdk:"gateway" json:"gateway,omitempty"`
+	Interfaces []InterfacePlan `tfsdk:"interfaces" json:"interfaces,omitempty"`
+
+	Site            string `tfsdk:"site" json:"site,omitempty"`
+	MirrorPort      string `tfsdk:"mirror_port" json:"mirror_port,omitempty"`
+	MirrorDirection string `tfsdk:"mirror_direction" json:"mirror_direction,omitempty"`
+}
+
+type InterfacePlan struct {
+	Name      string `tfsdk:"name" json:"name,omitempty"`
+	Node      string `tfsdk:"node" json:"node,omitempty"`
+	VLAN      string `tfsdk:"vlan" json:"vlan,omitempty"`
+	Bandwidth int64  `tfsdk:"bandwidth" json:"bandwidth,omitempty"`
+	MAC       string `tfsdk:"mac" json:"mac,omitempty"`
+	IPAddr    string `tfsdk:"ip_addr" json:"ip_addr,omitempty"`
+	NICModel  string `tfsdk:"nic_model" json:"nic_model,omitempty"`
 }
 
 // ---------- Converters ----------
@@ -95,12 +199,33 @@ func toStringSlice(v types.List) []string {
 	return out
 }
 
+func toStringMap(v types.Map) map[string]string {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	var out map[string]string
+	_ = v.ElementsAs(nil, &out, false)
+	return out
+}
+
+// sortedKeys returns the keys of m in order, so plans built from the name-keyed
+// maps are deterministic.
+func sortedKeys[V any](m map[string]V) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
 func FromTFPlan(tf TFPlan) Plan {
 	var topo TopologyPlan
 	if tf.Topology != nil {
-		for _, n := range tf.Topology.Nodes {
+		for _, name := range sortedKeys(tf.Topology.Nodes) {
+			n := tf.Topology.Nodes[name]
 			topo.Nodes = append(topo.Nodes, NodePlan{
-				Name:         toString(n.Name),
+				Name:         name,
 				Site:         toString(n.Site),
 				Type:         toString(n.Type),
 				ImageRef:     toString(n.ImageRef),
@@ -108,15 +233,30 @@ func FromTFPlan(tf TFPlan) Plan {
 				Cores:        toInt64(n.Cores),
 				RAM:          toInt64(n.RAM),
 				Disk:         toInt64(n.Disk),
+
+				PostBootScript: toString(n.PostBootScript),
 			})
 		}
-		for _, l := range tf.Topology.Links {
+		for _, name := range sortedKeys(tf.Topology.Links) {
+			l := tf.Topology.Links[name]
 			topo.Links = append(topo.Links, LinkPlan{
-				Name:   toString(l.Name),
+				Name:   name,
 				Source: toString(l.Source),
 				Target: toString(l.Target),
 			})
 		}
+		for _, fp := range tf.Topology.FacilityPorts {
+			topo.FacilityPorts = append(topo.FacilityPorts, FacilityPortPlan{
+				Name:      toString(fp.Name),
+				Site:      toString(fp.Site),
+				VLAN:      toString(fp.VLAN),
+				Bandwidth: toInt64(fp.Bandwidth),
+				Labels:    toStringMap(fp.Labels),
+			})
+		}
+		for _, ns := range tf.Topology.NetworkServices {
+			topo.NetworkServices = append(topo.NetworkServices, NetworkServiceFromTF(ns))
+		}
 	}
 
 	return Plan{
@@ -127,5 +267,61 @@ func FromTFPlan(tf TFPlan) Plan {
 		Topology:     topo,
 		State:        toString(tf.State),
 		SliverCount:  toInt64(tf.SliverCount),
+		GraphModel:   toString(tf.GraphModel),
+		TopologyDOT:  toString(tf.TopologyDOT),
+		ProjectID:    toString(tf.ProjectID),
+		Endpoint:     toString(tf.Endpoint),
+	}
+}
+
+func NetworkServiceFromTF(ns TFNetworkService) NetworkServicePlan {
+	svc := NetworkServicePlan{
+		Name:    toString(ns.Name),
+		Type:    toString(ns.Type),
+		Subnet:  toString(ns.Subnet),
+		Gateway: toString(ns.Gateway),
+
+		Site:            toString(ns.Site),
+		MirrorPort:      toString(ns.MirrorPort),
+		MirrorDirection: toString(ns.MirrorDirection),
+	}
+	for _, ifc := range ns.Interfaces {
+		svc.Interfaces = append(svc.Interfaces, InterfacePlan{
+			Name:      toString(ifc.Name),
+			Node:      toString(ifc.Node),
+			VLAN:      toString(ifc.VLAN),
+			Bandwidth: toInt64(ifc.Bandwidth),
+			MAC:       toString(ifc.MAC),
+			IPAddr:    toString(ifc.IPAddr),
+			NICModel:  toString(ifc.NICModel),
+		})
+	}
+	return svc
+}
+
+// NetworkServiceToTF converts a normalized service to its state value.
+// Subnet and gateway are left null until read back from the slice.
+func NetworkServiceToTF(ns NetworkServicePlan) TFNetworkService {
+	svc := TFNetworkService{
+		Name:    types.StringValue(ns.Name),
+		Type:    types.StringValue(ns.Type),
+		Subnet:  types.StringNull(),
+		Gateway: types.StringNull(),
+
+		Site:            optionalString(ns.Site),
+		MirrorPort:      optionalString(ns.MirrorPort),
+		MirrorDirection: optionalString(ns.MirrorDirection),
+	}
+	for _, ifc := range ns.Interfaces {
+		svc.Interfaces = append(svc.Interfaces, TFInterface{
+			Name:      types.StringValue(ifc.Name),
+			Node:      types.StringValue(ifc.Node),
+			VLAN:      optionalString(ifc.VLAN),
+			Bandwidth: optionalInt64(ifc.Bandwidth),
+			MAC:       optionalString(ifc.MAC),
+			IPAddr:    optionalString(ifc.IPAddr),
+			NICModel:  optionalString(ifc.NICModel),
+		})
 	}
+	return svc
 }
@@ -0,0 +1,60 @@
+package slice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func hasPostBootScripts(p Plan) bool {
+	for _, n := range p.Topology.Nodes {
+		if n.PostBootScript != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// runPostBootScripts runs each node's post_boot_script and records its exit
+// code and output in tf. Nodes are handled in name order; a node that cannot be
+// reached or whose script exits non-zero produces an error, but the
+// remaining nodes still run so state reflects every result.
+func (r *Resource) runPostBootScripts(ctx context.Context, tf *TFPlan) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if tf.Topology == nil {
+		return diags
+	}
+	for _, name := range sortedKeys(tf.Topology.Nodes) {
+		n := tf.Topology.Nodes[name]
+		n.PostBootExitCode = types.Int64Null()
+		n.PostBootOutput = types.StringNull()
+		tf.Topology.Nodes[name] = n
+
+		script := toString(n.PostBootScript)
+		if script == "" {
+			continue
+		}
+		ip, user := toString(n.ManagementIP), toString(n.Username)
+		if ip == "" || user == "" {
+			diags.AddError("Post-boot script not run",
+				fmt.Sprintf("Node %q has no management IP or known login user.", name))
+			continue
+		}
+
+		res, err := r.deps.Exec.RunScript(ctx, ip, user, script)
+		if err != nil {
+			diags.AddError("Post-boot script not run", fmt.Sprintf("Node %q: %s", name, err))
+			continue
+		}
+		n.PostBootExitCode = types.Int64Value(int64(res.ExitCode))
+		n.PostBootOutput = types.StringValue(res.Output)
+		tf.Topology.Nodes[name] = n
+		if res.ExitCode != 0 {
+			diags.AddError("Post-boot script failed",
+				fmt.Sprintf("Node %q: script exited with status %d.\n\n%s", name, res.ExitCode, res.Output))
+		}
+	}
+	return diags
+}
@@ -3,15 +3,21 @@ package slice
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 	"time"
 
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/clients/orchestrator"
 	"github.com/csc478-wcu/terraform-provider-fabric/internal/runtime"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/services"
 	"github.com/csc478-wcu/terraform-provider-fabric/internal/topology"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	rframework "github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var _ rframework.Resource = &Resource{}
@@ -21,6 +27,9 @@ type Resource struct {
 	deps *runtime.Deps
 }
 
+// defaultDeleteTimeout bounds how long destroy waits for a slice to close.
+const defaultDeleteTimeout = 20 * time.Minute
+
 func New() rframework.Resource { return &Resource{} }
 
 func (r *Resource) Metadata(_ context.Context, req rframework.MetadataRequest, resp *rframework.MetadataResponse) {
@@ -51,13 +60,32 @@ func (r *Resource) Create(ctx context.Context, req rframework.CreateRequest, res
 		return
 	}
 	p := FromTFPlan(tf)
+	if _, err := leasePolicyFrom(tf.AutoRenew); err != nil {
+		resp.Diagnostics.AddError("Invalid auto_renew", err.Error())
+		return
+	}
+	onFailure, err := onFailureFrom(tf.OnFailure)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid on_failure", err.Error())
+		return
+	}
+	if _, err := deleteTimeout(tf); err != nil {
+		resp.Diagnostics.AddError("Invalid timeouts", err.Error())
+		return
+	}
 
 	// 2) Normalize domain plan (apply provider defaults so everything is concrete)
-	pNorm := applyDefaultsToPlan(p)
-
-	// 3) Build GraphML from the normalized plan
-	graphID := uuid.New().String()
-	graph := planToGraphML(pNorm, graphID)
+	// 3) and build GraphML from it
+	pNorm, graph, err := BuildRequest(p, uuid.New().String())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid topology", err.Error())
+		return
+	}
+	if hasPostBootScripts(pNorm) && r.deps.Exec == nil {
+		resp.Diagnostics.AddError("Missing slice private key",
+			"post_boot_script needs the provider's 'slice_private_key' (or FABRIC_SLICE_PRIVATE_KEY) to log in to nodes.")
+		return
+	}
 
 	xmlStr, err := topology.Marshal(graph)
 	if err != nil {
@@ -82,38 +110,58 @@ func (r *Resource) Create(ctx context.Context, req rframework.CreateRequest, res
 		lease = time.Now().Add(24 * time.Hour).Format(time.RFC3339)
 	}
 
-	// 6) Create slice
-	id, state, slivers, leaseFinal, err := r.deps.Slices.Create(ctx, pNorm.Name, lease, xmlStr, keys)
+	// 6) Create slice in its project, or adopt the active slice holding its
+	// name when asked to
+	slices, err := r.deps.SlicesFor(ctx, pNorm.ProjectID)
 	if err != nil {
-		resp.Diagnostics.AddError("Create slice failed", err.Error())
+		resp.Diagnostics.AddError("Invalid project", err.Error())
 		return
 	}
-
-	// 7) Build TF topology value from the **normalized** plan (all concrete)
-	tfTopo := &TFTopology{
-		Nodes: make([]TFNode, 0, len(pNorm.Topology.Nodes)),
-		Links: make([]TFLink, 0, len(pNorm.Topology.Links)),
-	}
-	for _, n := range pNorm.Topology.Nodes {
-		tfTopo.Nodes = append(tfTopo.Nodes, TFNode{
-			Name:         types.StringValue(n.Name),
-			Site:         types.StringValue(n.Site),
-			Type:         types.StringValue(n.Type),
-			ImageRef:     types.StringValue(n.ImageRef),
-			InstanceType: types.StringValue(n.InstanceType),
-			Cores:        types.Int64Value(n.Cores),
-			RAM:          types.Int64Value(n.RAM),
-			Disk:         types.Int64Value(n.Disk),
-		})
+	existing, found, err := activeNamesake(ctx, slices, pNorm.Name)
+	if err != nil {
+		resp.Diagnostics.AddError("Checking slice name failed", err.Error())
+		return
 	}
-	for _, l := range pNorm.Topology.Links {
-		tfTopo.Links = append(tfTopo.Links, TFLink{
-			Name:   types.StringValue(l.Name),
-			Source: types.StringValue(l.Source),
-			Target: types.StringValue(l.Target),
-		})
+	var (
+		id, state, leaseFinal string
+		slivers               int
+	)
+	switch {
+	case found && !tf.AdoptExisting.ValueBool():
+		resp.Diagnostics.AddError("Slice name already in use",
+			fmt.Sprintf("Slice %s (%s) is already named %q. Choose another name, destroy or import that slice, "+
+				"or set adopt_existing = true to manage it from this resource.", existing.ID, existing.State, pNorm.Name))
+		return
+	case found:
+		sl, err := checkAdoptable(ctx, slices, existing.ID, topology.Decode(graph))
+		if err == nil {
+			var svs []orchestrator.Sliver
+			svs, err = slices.Slivers(ctx, sl.ID)
+			slivers = len(svs)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Cannot adopt existing slice", err.Error())
+			return
+		}
+		tflog.Info(ctx, "Adopting existing slice", map[string]interface{}{"slice_id": sl.ID, "name": pNorm.Name, "state": sl.State})
+		id, state, leaseFinal = sl.ID, sl.State, sl.LeaseEndTime
+	default:
+		id, state, slivers, leaseFinal, err = slices.Create(ctx, pNorm.Name, lease, xmlStr, keys)
+		if err != nil {
+			if closing := closingNamesake(ctx, slices, pNorm.Name); closing != "" {
+				resp.Diagnostics.AddError("Slice name still in use",
+					fmt.Sprintf("Slice %s, also named %q, is still closing. Apply again once it is Dead, or choose another name.\n\n%s",
+						closing, pNorm.Name, err))
+				return
+			}
+			resp.Diagnostics.AddError("Create slice failed", err.Error()+"\n\nSubmitted GraphML:\n"+xmlStr)
+			return
+		}
 	}
 
+	// 7) Build TF topology value from the **normalized** plan (all concrete)
+	tfTopo := topologyToTF(pNorm.Topology, tf.Topology)
+
 	// 8) Write state with concrete values
 	tfState := TFPlan{
 		ID:           types.StringValue(id),
@@ -122,7 +170,22 @@ func (r *Resource) Create(ctx context.Context, req rframework.CreateRequest, res
 		State:        types.StringValue(state),
 		SliverCount:  types.Int64Value(int64(slivers)),
 		Topology:     tfTopo, // normalized (no unknowns)
+		GraphModel:   types.StringValue(xmlStr),
+		TopologyDOT:  types.StringValue(topology.RenderDOT(topology.Decode(graph))),
+		ProjectID:    optionalString(pNorm.ProjectID),
+		Endpoint:     types.StringValue(r.deps.Endpoint),
+
+		LeaseStartTime:        types.StringNull(),
+		LeaseRemainingSeconds: types.Int64Null(),
+		AutoRenew:             tf.AutoRenew,
+		OnFailure:             tf.OnFailure,
+		Timeouts:              tf.Timeouts,
+		AdoptExisting:         tf.AdoptExisting,
+	}
+	if pNorm.ProjectID == "" {
+		tfState.ProjectID = optionalString(r.deps.ProjectID)
 	}
+	applySSHAccess(&tfState, r.deps.BastionHost, r.deps.BastionUsername)
 
 	// Preserve null vs list semantics for ssh_keys
 	if sshWasNull {
@@ -136,9 +199,97 @@ func (r *Resource) Create(ctx context.Context, req rframework.CreateRequest, res
 		tfState.SSHKeys = v
 	}
 
+	// 9) Wait for the slice to settle, then read back orchestrator-assigned
+	// values. If waiting fails the slice still exists, so unless on_failure
+	// deletes it, record it (the error taints it) rather than leaking it.
+	sl, err := slices.WaitStable(ctx, id)
+	if err != nil {
+		createFailed(ctx, slices, onFailure, &tfState, resp, "Waiting for slice failed", err.Error())
+		return
+	}
+	tfState.State = types.StringValue(sl.State)
+	if sl.ProjectID != "" {
+		tfState.ProjectID = types.StringValue(sl.ProjectID)
+	}
+	resp.Diagnostics.Append(ApplySliceModel(tfState.Topology, sl.Model, sl.ModelFormat)...)
+	applySSHAccess(&tfState, r.deps.BastionHost, r.deps.BastionUsername)
+	resp.Diagnostics.Append(refreshLease(ctx, slices, &tfState, sl, false)...)
+	if sl.State != "StableOK" {
+		detail := fmt.Sprintf("Slice %s settled in state %s.", id, sl.State)
+		if onFailure == onFailureKeep {
+			resp.Diagnostics.AddWarning("Slice is not healthy", detail)
+		} else {
+			createFailed(ctx, slices, onFailure, &tfState, resp, "Slice is not healthy", detail)
+			return
+		}
+	}
+
+	// 10) Post-boot configuration; failures are recorded and fail the apply
+	if sl.State == "StableOK" && hasPostBootScripts(pNorm) {
+		resp.Diagnostics.Append(r.runPostBootScripts(ctx, &tfState)...)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &tfState)...)
 }
 
+// topologyToTF converts a normalized topology to its state value. cfg is the
+// configured topology, or nil for an imported slice; where null and empty
+// mean the same, it decides which one state records.
+func topologyToTF(t TopologyPlan, cfg *TFTopology) *TFTopology {
+	tfTopo := &TFTopology{
+		Nodes: make(map[string]TFNode, len(t.Nodes)),
+	}
+	for _, n := range t.Nodes {
+		// VM-only fields stay null on switches
+		tfTopo.Nodes[n.Name] = TFNode{
+			Site:         types.StringValue(n.Site),
+			Type:         types.StringValue(n.Type),
+			ImageRef:     optionalString(n.ImageRef),
+			InstanceType: optionalString(n.InstanceType),
+			Cores:        optionalInt64(n.Cores),
+			RAM:          optionalInt64(n.RAM),
+			Disk:         optionalInt64(n.Disk),
+			ManagementIP: types.StringNull(),
+
+			PostBootScript:   optionalString(n.PostBootScript),
+			PostBootExitCode: types.Int64Null(),
+			PostBootOutput:   types.StringNull(),
+		}
+	}
+	if (cfg != nil && cfg.Links != nil) || (cfg == nil && len(t.Links) > 0) {
+		tfTopo.Links = make(map[string]TFLink, len(t.Links))
+	}
+	for _, l := range t.Links {
+		tfTopo.Links[l.Name] = TFLink{
+			Source: types.StringValue(l.Source),
+			Target: types.StringValue(l.Target),
+		}
+	}
+	for i, fp := range t.FacilityPorts {
+		labels := types.MapNull(types.StringType)
+		if cfg != nil {
+			labels = cfg.FacilityPorts[i].Labels // keep null vs {} as configured
+		} else if len(fp.Labels) > 0 {
+			elems := make(map[string]attr.Value, len(fp.Labels))
+			for k, v := range fp.Labels {
+				elems[k] = types.StringValue(v)
+			}
+			labels = types.MapValueMust(types.StringType, elems)
+		}
+		tfTopo.FacilityPorts = append(tfTopo.FacilityPorts, TFFacilityPort{
+			Name:      types.StringValue(fp.Name),
+			Site:      types.StringValue(fp.Site),
+			VLAN:      optionalString(fp.VLAN),
+			Bandwidth: types.Int64Value(fp.Bandwidth),
+			Labels:    labels,
+		})
+	}
+	for _, ns := range t.NetworkServices {
+		tfTopo.NetworkServices = append(tfTopo.NetworkServices, NetworkServiceToTF(ns))
+	}
+	return tfTopo
+}
+
 // applyDefaultsToPlan returns a copy of p with all node fields concretized
 func applyDefaultsToPlan(p Plan) Plan {
 	const (
@@ -148,6 +299,10 @@ func applyDefaultsToPlan(p Plan) Plan {
 		defCores        int64 = 2
 		defRAM          int64 = 2
 		defDisk         int64 = 10
+
+		defFacilityBandwidth int64 = 10
+		defServiceType             = "L2Bridge"
+		defNICModel                = "NIC_Basic"
 	)
 	out := p
 	// Normalize nodes
@@ -160,6 +315,12 @@ func applyDefaultsToPlan(p Plan) Plan {
 		if nn.Type == "" {
 			nn.Type = defType
 		}
+		if nn.Type == "Switch" {
+			// P4 switches are sized by the hardware; leave VM fields unset
+			// so validation can reject them if the user supplied any.
+			out.Topology.Nodes = append(out.Topology.Nodes, nn)
+			continue
+		}
 		if nn.ImageRef == "" {
 			nn.ImageRef = defImage
 		}
@@ -179,9 +340,60 @@ func applyDefaultsToPlan(p Plan) Plan {
 	}
 	// Links don't have defaults besides names already set in plan, just copy
 	out.Topology.Links = append([]LinkPlan(nil), p.Topology.Links...)
+	// Normalize network services
+	facilityPorts := make(map[string]bool, len(p.Topology.FacilityPorts))
+	for _, fp := range p.Topology.FacilityPorts {
+		facilityPorts[fp.Name] = true
+	}
+	switches := map[string]bool{}
+	for _, n := range out.Topology.Nodes {
+		if n.Type == "Switch" {
+			switches[n.Name] = true
+		}
+	}
+	out.Topology.NetworkServices = nil
+	for _, ns := range p.Topology.NetworkServices {
+		if ns.Type == "" {
+			ns.Type = defServiceType
+		}
+		ifcs := make([]InterfacePlan, 0, len(ns.Interfaces))
+		for j, ifc := range ns.Interfaces {
+			if ifc.Name == "" {
+				ifc.Name = fmt.Sprintf("%s-%s-%d", ns.Name, ifc.Node, j+1)
+			}
+			if ifc.NICModel == "" && !facilityPorts[ifc.Node] && !switches[ifc.Node] {
+				ifc.NICModel = defNICModel
+			}
+			ifcs = append(ifcs, ifc)
+		}
+		ns.Interfaces = ifcs
+		out.Topology.NetworkServices = append(out.Topology.NetworkServices, ns)
+	}
+	// Normalize facility ports
+	out.Topology.FacilityPorts = nil
+	for _, fp := range p.Topology.FacilityPorts {
+		if fp.Bandwidth == 0 {
+			fp.Bandwidth = defFacilityBandwidth
+		}
+		out.Topology.FacilityPorts = append(out.Topology.FacilityPorts, fp)
+	}
 	return out
 }
 
+func optionalString(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
+
+func optionalInt64(v int64) types.Int64 {
+	if v == 0 {
+		return types.Int64Null()
+	}
+	return types.Int64Value(v)
+}
+
 func (r *Resource) Read(ctx context.Context, req rframework.ReadRequest, resp *rframework.ReadResponse) {
 	// Read prior state using TF types (handles null/unknown safely)
 	var tf TFPlan
@@ -196,7 +408,15 @@ func (r *Resource) Read(ctx context.Context, req rframework.ReadRequest, resp *r
 		return
 	}
 
-	name, state, err := r.deps.Slices.Get(ctx, id)
+	if !CheckEndpoint(r.deps, toString(tf.ID), tf.Endpoint, &resp.Diagnostics) {
+		return
+	}
+	slices, err := StateSlices(ctx, r.deps, tf.ProjectID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project", err.Error())
+		return
+	}
+	sl, err := slices.Get(ctx, id)
 	if err != nil {
 		// if remote is gone, remove from state
 		if strings.Contains(err.Error(), "not found") || strings.Contains(strings.ToLower(err.Error()), "no slices") {
@@ -208,14 +428,164 @@ func (r *Resource) Read(ctx context.Context, req rframework.ReadRequest, resp *r
 	}
 
 	// Update only the fields we learned; keep the rest (including any nulls) as-is
-	tf.Name = types.StringValue(name)
-	tf.State = types.StringValue(state)
+	tf.Name = types.StringValue(sl.Name)
+	tf.State = types.StringValue(sl.State)
+	tf.Endpoint = types.StringValue(r.deps.Endpoint) // imported or older state
+	if sl.ProjectID != "" {
+		tf.ProjectID = types.StringValue(sl.ProjectID)
+	}
+	if tf.Topology == nil { // imported
+		resp.Diagnostics.Append(importTopology(&tf, sl)...)
+	}
+	resp.Diagnostics.Append(ApplySliceModel(tf.Topology, sl.Model, sl.ModelFormat)...)
+	applySSHAccess(&tf, r.deps.BastionHost, r.deps.BastionUsername)
+	resp.Diagnostics.Append(refreshLease(ctx, slices, &tf, sl, true)...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &tf)...)
 }
 
-func (r *Resource) Update(_ context.Context, _ rframework.UpdateRequest, resp *rframework.UpdateResponse) {
-	resp.Diagnostics.AddError("Update not supported", "Modify the slice by destroying and re-creating it.")
+// CheckEndpoint refuses to act on a slice created through a different
+// orchestrator, which happens when provider aliases are swapped between
+// resources. Slice IDs are per-orchestrator, so the call would either miss
+// or hit an unrelated slice. endpoint is the one recorded in state.
+func CheckEndpoint(deps *runtime.Deps, sliceID string, endpoint types.String, diags *diag.Diagnostics) bool {
+	recorded := toString(endpoint)
+	if recorded == "" || sameEndpoint(recorded, deps.Endpoint) {
+		return true
+	}
+	diags.AddError("Slice belongs to a different orchestrator",
+		fmt.Sprintf("Slice %s was created through %s, but this provider is configured for %s. "+
+			"Use the provider configuration (alias) for %s with this resource.",
+			sliceID, recorded, deps.Endpoint, recorded))
+	return false
+}
+
+func sameEndpoint(a, b string) bool {
+	return strings.TrimRight(a, "/") == strings.TrimRight(b, "/")
+}
+
+// StateSlices returns the slices service for a slice already in state,
+// given the project recorded with it. Without a refresh token the
+// provider's own token is the only one available, so it serves every slice
+// regardless of the recorded project.
+func StateSlices(ctx context.Context, deps *runtime.Deps, projectID types.String) (services.SlicesService, error) {
+	if deps.RefreshToken == "" {
+		return deps.Slices, nil
+	}
+	return deps.SlicesFor(ctx, toString(projectID))
+}
+
+// SliceProject returns the project to record for sl: the one the
+// orchestrator reports, else the one already recorded, else the provider's.
+func SliceProject(deps *runtime.Deps, recorded types.String, sl orchestrator.Slice) types.String {
+	switch {
+	case sl.ProjectID != "":
+		return types.StringValue(sl.ProjectID)
+	case toString(recorded) != "":
+		return recorded
+	}
+	return optionalString(deps.ProjectID)
+}
+
+// Update handles the attributes that can change without re-creating the
+// slice: lease_end_time (renewed through the orchestrator) and auto_renew.
+func (r *Resource) Update(ctx context.Context, req rframework.UpdateRequest, resp *rframework.UpdateResponse) {
+	var plan, state TFPlan
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if changed := changedInPlace(plan, state); len(changed) > 0 {
+		resp.Diagnostics.AddError("Update not supported",
+			fmt.Sprintf("Only lease_end_time and the settings auto_renew, on_failure, timeouts and adopt_existing can change in place, but %s changed. "+
+				"Modify the slice by destroying and re-creating it.", strings.Join(changed, ", ")))
+		return
+	}
+	if _, err := leasePolicyFrom(plan.AutoRenew); err != nil {
+		resp.Diagnostics.AddError("Invalid auto_renew", err.Error())
+		return
+	}
+	if _, err := onFailureFrom(plan.OnFailure); err != nil {
+		resp.Diagnostics.AddError("Invalid on_failure", err.Error())
+		return
+	}
+	if _, err := deleteTimeout(plan); err != nil {
+		resp.Diagnostics.AddError("Invalid timeouts", err.Error())
+		return
+	}
+	if !CheckEndpoint(r.deps, toString(state.ID), state.Endpoint, &resp.Diagnostics) {
+		return
+	}
+	slices, err := StateSlices(ctx, r.deps, state.ProjectID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project", err.Error())
+		return
+	}
+
+	id := toString(state.ID)
+	next := state
+	next.AutoRenew = plan.AutoRenew
+	next.OnFailure = plan.OnFailure
+	next.Timeouts = plan.Timeouts
+	next.AdoptExisting = plan.AdoptExisting
+	if want := toString(plan.LeaseEndTime); want != "" && want != toString(state.LeaseEndTime) {
+		if _, err := slices.Renew(ctx, id, want); err != nil {
+			resp.Diagnostics.AddError("Renew slice failed", err.Error())
+			return
+		}
+		next.LeaseEndTime = plan.LeaseEndTime
+	}
+
+	sl, err := slices.Get(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError("Read slice failed", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(refreshLease(ctx, slices, &next, sl, false)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &next)...)
+}
+
+// changedInPlace lists the configurable attributes, other than the lease
+// settings and the options that only steer the provider (on_failure,
+// timeouts, adopt_existing), whose planned value differs from state.
+// Computed values the plan leaves unknown (defaults, orchestrator-assigned
+// addresses) don't count.
+func changedInPlace(plan, state TFPlan) []string {
+	var changed []string
+	if toString(plan.Name) != toString(state.Name) {
+		changed = append(changed, "name")
+	}
+	if !plan.SSHKeys.Equal(state.SSHKeys) {
+		changed = append(changed, "ssh_keys")
+	}
+	if p := toString(plan.ProjectID); p != "" && p != toString(state.ProjectID) {
+		changed = append(changed, "project_id")
+	}
+
+	p := applyDefaultsToPlan(FromTFPlan(plan)).Topology
+	s := applyDefaultsToPlan(FromTFPlan(state)).Topology
+	for _, topo := range []*TopologyPlan{&p, &s} {
+		for i := range topo.NetworkServices {
+			topo.NetworkServices[i].Subnet, topo.NetworkServices[i].Gateway = "", ""
+		}
+	}
+	if len(p.NetworkServices) == len(s.NetworkServices) {
+		for i, ns := range p.NetworkServices {
+			if len(ns.Interfaces) != len(s.NetworkServices[i].Interfaces) {
+				continue
+			}
+			for j, ifc := range ns.Interfaces {
+				if ifc.IPAddr == "" {
+					s.NetworkServices[i].Interfaces[j].IPAddr = ""
+				}
+			}
+		}
+	}
+	if !reflect.DeepEqual(p, s) {
+		changed = append(changed, "topology")
+	}
+	return changed
 }
 
 func (r *Resource) Delete(ctx context.Context, req rframework.DeleteRequest, resp *rframework.DeleteResponse) {
@@ -230,11 +600,39 @@ func (r *Resource) Delete(ctx context.Context, req rframework.DeleteRequest, res
 	if id == "" {
 		return // nothing to delete
 	}
-	if err := r.deps.Slices.Delete(ctx, id); err != nil {
+	if !CheckEndpoint(r.deps, toString(tf.ID), tf.Endpoint, &resp.Diagnostics) {
+		return
+	}
+	slices, err := StateSlices(ctx, r.deps, tf.ProjectID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project", err.Error())
+		return
+	}
+	timeout, err := deleteTimeout(tf)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid timeouts", err.Error())
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if err := slices.Delete(ctx, id); err != nil {
 		resp.Diagnostics.AddError("Delete slice failed", err.Error())
 	}
 }
 
+// deleteTimeout is timeouts.delete, or defaultDeleteTimeout when unset.
+func deleteTimeout(tf TFPlan) (time.Duration, error) {
+	if tf.Timeouts == nil || toString(tf.Timeouts.Delete) == "" {
+		return defaultDeleteTimeout, nil
+	}
+	s := toString(tf.Timeouts.Delete)
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("timeouts.delete: %q is not a valid duration (e.g. \"90s\", \"20m\")", s)
+	}
+	return d, nil
+}
+
 func (r *Resource) ImportState(ctx context.Context, req rframework.ImportStateRequest, resp *rframework.ImportStateResponse) {
 	rframework.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
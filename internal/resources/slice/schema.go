@@ -2,16 +2,26 @@ package slice
 
 import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 func Schema() schema.Schema {
+	// Update never changes these, so in-place plans keep the state values
+	// instead of marking them (known after apply).
+	keep := []planmodifier.String{stringplanmodifier.UseStateForUnknown()}
+	keepInt := []planmodifier.Int64{int64planmodifier.UseStateForUnknown()}
+
 	return schema.Schema{
+		Version:             1,
 		MarkdownDescription: "Manages a FABRIC slice.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Slice identifier.",
 				Computed:            true,
+				PlanModifiers:       keep,
 			},
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Slice name.",
@@ -22,6 +32,51 @@ func Schema() schema.Schema {
 				Optional:            true,
 				Computed:            true,
 			},
+			"lease_start_time": schema.StringAttribute{
+				MarkdownDescription: "Lease start time reported by the orchestrator.",
+				Computed:            true,
+			},
+			"lease_remaining_seconds": schema.Int64Attribute{
+				MarkdownDescription: "Seconds left on the lease as of the last refresh.",
+				Computed:            true,
+			},
+			"auto_renew": schema.SingleNestedAttribute{
+				MarkdownDescription: "Renew the lease on refresh once it is close to expiring. Leave `lease_end_time` unset (or ignore its changes) so renewals don't show as drift.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"renew_before": schema.StringAttribute{
+						MarkdownDescription: "Renew when less than this much lease is left, e.g. `24h` or `2d`. Defaults to `24h`.",
+						Optional:            true,
+					},
+					"renew_duration": schema.StringAttribute{
+						MarkdownDescription: "New lease length, counted from the renewal. Defaults to `7d`.",
+						Optional:            true,
+					},
+					"max_lease": schema.StringAttribute{
+						MarkdownDescription: "Longest total lifetime, counted from `lease_start_time`; renewals stop there. Unlimited when unset.",
+						Optional:            true,
+					},
+				},
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "If an active slice already has `name`, manage it instead of failing the create, provided its topology matches `topology`.",
+				Optional:            true,
+			},
+			"on_failure": schema.StringAttribute{
+				MarkdownDescription: "What to do when the new slice does not settle in `StableOK`: `keep` it in state with a warning (default), `taint` it so the next apply replaces it, or `delete` it and report the failed slivers.",
+				Optional:            true,
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "FABRIC project owning the slice. Defaults to the provider's `project_id`; a different project needs the provider's `refresh_token`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers:       keep,
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "Orchestrator endpoint the slice was created through. Providers configured for another endpoint refuse to manage the slice.",
+				Computed:            true,
+				PlanModifiers:       keep,
+			},
 			"ssh_keys": schema.ListAttribute{
 				MarkdownDescription: "SSH public keys.",
 				ElementType:         types.StringType,
@@ -35,34 +90,193 @@ func Schema() schema.Schema {
 				MarkdownDescription: "Number of slivers in the slice.",
 				Computed:            true,
 			},
+			"graph_model": schema.StringAttribute{
+				MarkdownDescription: "GraphML request submitted to the orchestrator.",
+				Computed:            true,
+				PlanModifiers:       keep,
+			},
+			"topology_dot": schema.StringAttribute{
+				MarkdownDescription: "Graphviz DOT rendering of the requested nodes, components and services.",
+				Computed:            true,
+				PlanModifiers:       keep,
+			},
+			"ssh_config": schema.StringAttribute{
+				MarkdownDescription: "OpenSSH config with a host entry per node, jumping through the provider's bastion host.",
+				Computed:            true,
+				PlanModifiers:       keep,
+			},
+			"timeouts": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"delete": schema.StringAttribute{
+						MarkdownDescription: "How long destroy waits for the slice to reach `Dead`, as a Go duration. Defaults to `20m`.",
+						Optional:            true,
+					},
+				},
+			},
 			"topology": schema.SingleNestedAttribute{
 				Required: true,
 				Attributes: map[string]schema.Attribute{
-					"nodes": schema.ListNestedAttribute{
-						Required: true,
+					"nodes": schema.MapNestedAttribute{
+						MarkdownDescription: "Nodes keyed by name.",
+						Required:            true,
 						NestedObject: schema.NestedAttributeObject{
 							Attributes: map[string]schema.Attribute{
-								"name":          schema.StringAttribute{Required: true},
-								"site":          schema.StringAttribute{Required: true},
-								"type":          schema.StringAttribute{Optional: true, Computed: true},
+								"site": schema.StringAttribute{Required: true},
+								"type": schema.StringAttribute{
+									MarkdownDescription: "Node type: `VM` (default) or `Switch` (P4). Switches take no `image_ref`, `instance_type`, `cores`, `ram` or `disk`.",
+									Optional:            true,
+									Computed:            true,
+								},
 								"image_ref":     schema.StringAttribute{Optional: true, Computed: true},
 								"instance_type": schema.StringAttribute{Optional: true, Computed: true},
 								"cores":         schema.Int64Attribute{Optional: true, Computed: true},
 								"ram":           schema.Int64Attribute{Optional: true, Computed: true},
 								"disk":          schema.Int64Attribute{Optional: true, Computed: true},
+								"management_ip": schema.StringAttribute{
+									MarkdownDescription: "Management IP assigned once the node is active.",
+									Computed:            true,
+									PlanModifiers:       keep,
+								},
+								"username": schema.StringAttribute{
+									MarkdownDescription: "Default login user of the node's image.",
+									Computed:            true,
+									PlanModifiers:       keep,
+								},
+								"ssh_command": schema.StringAttribute{
+									MarkdownDescription: "Command to log in to the node through the bastion host.",
+									Computed:            true,
+									PlanModifiers:       keep,
+								},
+								"post_boot_script": schema.StringAttribute{
+									MarkdownDescription: "Script run with `bash` over SSH (through the bastion) once the slice is `StableOK`. A non-zero exit fails the apply. Requires the provider's `slice_private_key`.",
+									Optional:            true,
+								},
+								"post_boot_exit_code": schema.Int64Attribute{
+									MarkdownDescription: "Exit code of `post_boot_script`.",
+									Computed:            true,
+									PlanModifiers:       keepInt,
+								},
+								"post_boot_output": schema.StringAttribute{
+									MarkdownDescription: "Combined output of `post_boot_script`, trimmed to its last 4 KiB.",
+									Computed:            true,
+									PlanModifiers:       keep,
+								},
 							},
 						},
 					},
-					"links": schema.ListNestedAttribute{
-						Optional: true,
+					"links": schema.MapNestedAttribute{
+						MarkdownDescription: "Links keyed by name.",
+						Optional:            true,
 						NestedObject: schema.NestedAttributeObject{
 							Attributes: map[string]schema.Attribute{
-								"name":   schema.StringAttribute{Required: true},
 								"source": schema.StringAttribute{Required: true},
 								"target": schema.StringAttribute{Required: true},
 							},
 						},
 					},
+					"network_services": schema.ListNestedAttribute{
+						MarkdownDescription: "Network services connecting node and facility port interfaces.",
+						Optional:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: NetworkServiceAttributes(),
+						},
+					},
+					"facility_ports": schema.ListNestedAttribute{
+						MarkdownDescription: "Facility ports (campus networks, cloud interconnects) that links can attach to by name.",
+						Optional:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"name": schema.StringAttribute{Required: true},
+								"site": schema.StringAttribute{Required: true},
+								"vlan": schema.StringAttribute{
+									MarkdownDescription: "VLAN tag to request on the port.",
+									Optional:            true,
+								},
+								"bandwidth": schema.Int64Attribute{
+									MarkdownDescription: "Bandwidth in Gbps. Defaults to 10.",
+									Optional:            true,
+									Computed:            true,
+								},
+								"labels": schema.MapAttribute{
+									MarkdownDescription: "Additional interface labels (e.g. `local_name`, `device_name`).",
+									ElementType:         types.StringType,
+									Optional:            true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// NetworkServiceAttributes is the schema of one network service, shared by
+// fabric_slice's topology and the fabric_network_service resource.
+func NetworkServiceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{Required: true},
+		"type": schema.StringAttribute{
+			MarkdownDescription: "Service type: `L2Bridge`, `L2STS`, `L2PTP`, `FABNetv4`, `FABNetv6` or `PortMirror`. Defaults to `L2Bridge`.",
+			Optional:            true,
+			Computed:            true,
+		},
+		"site": schema.StringAttribute{
+			MarkdownDescription: "Site of the service. Required for `PortMirror`; otherwise derived from the interfaces.",
+			Optional:            true,
+		},
+		"mirror_port": schema.StringAttribute{
+			MarkdownDescription: "`PortMirror` only: name of the switch port to mirror, as listed in the site advertisement.",
+			Optional:            true,
+		},
+		"mirror_direction": schema.StringAttribute{
+			MarkdownDescription: "`PortMirror` only: `both`, `rx` or `tx`. Defaults to `both`.",
+			Optional:            true,
+		},
+		"subnet": schema.StringAttribute{
+			MarkdownDescription: "Subnet assigned by the orchestrator (FABNet services).",
+			Computed:            true,
+		},
+		"gateway": schema.StringAttribute{
+			MarkdownDescription: "Gateway address assigned by the orchestrator (FABNet services).",
+			Computed:            true,
+		},
+		"interfaces": schema.ListNestedAttribute{
+			Required: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						MarkdownDescription: "Interface name. Defaults to `<service>-<node>-<n>`.",
+						Optional:            true,
+						Computed:            true,
+					},
+					"node": schema.StringAttribute{
+						MarkdownDescription: "Node or facility port the interface belongs to.",
+						Required:            true,
+					},
+					"vlan": schema.StringAttribute{
+						MarkdownDescription: "VLAN tag.",
+						Optional:            true,
+					},
+					"bandwidth": schema.Int64Attribute{
+						MarkdownDescription: "Bandwidth in Gbps.",
+						Optional:            true,
+					},
+					"mac": schema.StringAttribute{
+						MarkdownDescription: "MAC address.",
+						Optional:            true,
+					},
+					"ip_addr": schema.StringAttribute{
+						MarkdownDescription: "IP address of the interface; read back from the slice once it is stable.",
+						Optional:            true,
+						Computed:            true,
+					},
+					"nic_model": schema.StringAttribute{
+						MarkdownDescription: "NIC backing the interface on a node: `NIC_Basic` (shared, default), `NIC_ConnectX_5` or `NIC_ConnectX_6` (dedicated SmartNICs).",
+						Optional:            true,
+						Computed:            true,
+					},
 				},
 			},
 		},
@@ -0,0 +1,133 @@
+package slice_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/provider"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/resources/slice"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func dynamicValue(t *testing.T, tf slice.TFPlan) *tfprotov6.DynamicValue {
+	t.Helper()
+	ctx := context.Background()
+	schema := slice.Schema()
+	state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}
+	if diags := state.Set(ctx, &tf); diags.HasError() {
+		t.Fatalf("encode: %v", diags)
+	}
+	dv, err := tfprotov6.NewDynamicValue(schema.Type().TerraformType(ctx), state.Raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &dv
+}
+
+// TestPlanAutoRenewOnly checks that changing only auto_renew plans an
+// in-place update that keeps the values Update leaves alone.
+func TestPlanAutoRenewOnly(t *testing.T) {
+	ctx := context.Background()
+	config := slice.TFPlan{
+		Name:    types.StringValue("demo"),
+		SSHKeys: types.ListNull(types.StringType),
+		Topology: &slice.TFTopology{Nodes: map[string]slice.TFNode{
+			"n1": {Site: types.StringValue("UCSD"), PostBootScript: types.StringValue("echo hi")},
+		}},
+		AutoRenew: &slice.TFAutoRenew{RenewBefore: types.StringValue("48h")},
+	}
+
+	prior := config
+	prior.AutoRenew = nil
+	prior.ID = types.StringValue("6b1f2c3a-0000-4000-8000-000000000001")
+	prior.LeaseEndTime = types.StringValue("2030-01-02 03:04:05 +0000")
+	prior.LeaseStartTime = types.StringValue("2030-01-01 03:04:05 +0000")
+	prior.LeaseRemainingSeconds = types.Int64Value(3600)
+	prior.ProjectID = types.StringValue("proj")
+	prior.Endpoint = types.StringValue("https://orchestrator.fabric-testbed.net")
+	prior.State = types.StringValue("StableOK")
+	prior.SliverCount = types.Int64Value(1)
+	prior.GraphModel = types.StringValue("<graphml/>")
+	prior.TopologyDOT = types.StringValue("graph {}")
+	prior.SSHConfig = types.StringValue("Host n1")
+	prior.Topology = &slice.TFTopology{Nodes: map[string]slice.TFNode{
+		"n1": {
+			Site:             types.StringValue("UCSD"),
+			Type:             types.StringValue("VM"),
+			ImageRef:         types.StringValue("default_rocky_8"),
+			Cores:            types.Int64Value(2),
+			RAM:              types.Int64Value(8),
+			Disk:             types.Int64Value(10),
+			ManagementIP:     types.StringValue("10.0.0.1"),
+			Username:         types.StringValue("rocky"),
+			SSHCommand:       types.StringValue("ssh n1"),
+			PostBootScript:   types.StringValue("echo hi"),
+			PostBootExitCode: types.Int64Value(0),
+			PostBootOutput:   types.StringValue("hi"),
+		},
+	}}
+
+	// Terraform proposes the prior computed values with the new config.
+	proposed := prior
+	proposed.AutoRenew = config.AutoRenew
+
+	server, err := providerserver.NewProtocol6WithError(provider.New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         "fabric_slice",
+		PriorState:       dynamicValue(t, prior),
+		ProposedNewState: dynamicValue(t, proposed),
+		Config:           dynamicValue(t, config),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Errorf("diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+	if len(resp.RequiresReplace) != 0 {
+		t.Errorf("requires replace: %v", resp.RequiresReplace)
+	}
+
+	schema := slice.Schema()
+	raw, err := resp.PlannedState.Unmarshal(schema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var plan slice.TFPlan
+	if diags := (tfsdk.Plan{Schema: schema, Raw: raw}).Get(ctx, &plan); diags.HasError() {
+		t.Fatalf("decode plan: %v", diags)
+	}
+
+	// Control: computed values Update does change are still unknown.
+	if !plan.LeaseRemainingSeconds.IsUnknown() {
+		t.Errorf("lease_remaining_seconds = %v, want unknown", plan.LeaseRemainingSeconds)
+	}
+
+	kept := map[string][2]attr.Value{
+		"id":           {plan.ID, prior.ID},
+		"endpoint":     {plan.Endpoint, prior.Endpoint},
+		"project_id":   {plan.ProjectID, prior.ProjectID},
+		"graph_model":  {plan.GraphModel, prior.GraphModel},
+		"topology_dot": {plan.TopologyDOT, prior.TopologyDOT},
+		"ssh_config":   {plan.SSHConfig, prior.SSHConfig},
+	}
+	n, pn := plan.Topology.Nodes["n1"], prior.Topology.Nodes["n1"]
+	kept["n1.management_ip"] = [2]attr.Value{n.ManagementIP, pn.ManagementIP}
+	kept["n1.username"] = [2]attr.Value{n.Username, pn.Username}
+	kept["n1.ssh_command"] = [2]attr.Value{n.SSHCommand, pn.SSHCommand}
+	kept["n1.post_boot_exit_code"] = [2]attr.Value{n.PostBootExitCode, pn.PostBootExitCode}
+	kept["n1.post_boot_output"] = [2]attr.Value{n.PostBootOutput, pn.PostBootOutput}
+	for name, v := range kept {
+		if !v[0].Equal(v[1]) {
+			t.Errorf("%s = %v, want %v", name, v[0], v[1])
+		}
+	}
+}
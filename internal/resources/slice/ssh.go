@@ -0,0 +1,96 @@
+package slice
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// bastionAlias is the Host name the bastion gets in the generated ssh_config.
+const bastionAlias = "fabric-bastion"
+
+// imageUsers maps image name prefixes to the default login user, following
+// FABRIC's stock images (default_rocky_8, docker_ubuntu_22, ...).
+var imageUsers = []struct{ prefix, user string }{
+	{"default_rocky", "rocky"},
+	{"default_ubuntu", "ubuntu"},
+	{"default_centos", "centos"},
+	{"default_debian", "debian"},
+	{"default_fedora", "fedora"},
+	{"default_cirros", "cirros"},
+	{"default_kali", "kali"},
+	{"default_freebsd", "freebsd"},
+	{"default_openbsd", "openbsd"},
+	{"docker_rocky", "rocky"},
+	{"docker_ubuntu", "ubuntu"},
+	{"attestable_bmv2", "ubuntu"},
+}
+
+// imageUsername returns the login user of an image, or "" when unknown.
+func imageUsername(imageRef string) string {
+	image, _, _ := strings.Cut(imageRef, ",")
+	for _, iu := range imageUsers {
+		if strings.HasPrefix(image, iu.prefix) {
+			return iu.user
+		}
+	}
+	return ""
+}
+
+// applySSHAccess fills each node's username and ssh_command and renders the
+// slice ssh_config from the management IPs already in tf. Nodes without a
+// management IP (switches, VMs not yet active) are left out.
+func applySSHAccess(tf *TFPlan, bastionHost, bastionUser string) {
+	tf.SSHConfig = types.StringNull()
+	if tf.Topology == nil {
+		return
+	}
+
+	var b strings.Builder
+	if bastionHost != "" {
+		fmt.Fprintf(&b, "Host %s\n  HostName %s\n", bastionAlias, bastionHost)
+		if bastionUser != "" {
+			fmt.Fprintf(&b, "  User %s\n", bastionUser)
+		}
+	}
+
+	hosts := 0
+	for _, name := range sortedKeys(tf.Topology.Nodes) {
+		n := tf.Topology.Nodes[name]
+		n.ManagementIP = nullIfUnknown(n.ManagementIP)
+		n.Username = types.StringNull()
+		n.SSHCommand = types.StringNull()
+
+		user := imageUsername(toString(n.ImageRef))
+		if user != "" {
+			n.Username = types.StringValue(user)
+		}
+		ip := toString(n.ManagementIP)
+		tf.Topology.Nodes[name] = n
+		if ip == "" || user == "" {
+			continue
+		}
+
+		cmd := "ssh"
+		if bastionHost != "" {
+			jump := bastionHost
+			if bastionUser != "" {
+				jump = bastionUser + "@" + bastionHost
+			}
+			cmd += " -J " + jump
+		}
+		n.SSHCommand = types.StringValue(fmt.Sprintf("%s %s@%s", cmd, user, ip))
+		tf.Topology.Nodes[name] = n
+
+		fmt.Fprintf(&b, "\nHost %s\n  HostName %s\n  User %s\n", name, ip, user)
+		if bastionHost != "" {
+			fmt.Fprintf(&b, "  ProxyJump %s\n", bastionAlias)
+		}
+		hosts++
+	}
+
+	if hosts > 0 {
+		tf.SSHConfig = types.StringValue(b.String())
+	}
+}
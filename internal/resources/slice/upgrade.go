@@ -0,0 +1,297 @@
+package slice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	rframework "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ rframework.ResourceWithUpgradeState = &Resource{}
+
+// UpgradeState migrates fabric_slice state written by earlier schema
+// versions. Prior schemas and models are frozen copies; they must not follow
+// later changes to Schema or the TF types.
+func (r *Resource) UpgradeState(_ context.Context) map[int64]rframework.StateUpgrader {
+	v0 := schemaV0()
+	return map[int64]rframework.StateUpgrader{
+		0: {PriorSchema: &v0, StateUpgrader: upgradeFromV0},
+	}
+}
+
+// ---------- v0: nodes and links as lists ----------
+
+func schemaV0() schema.Schema {
+	str := func(required, optional, computed bool) schema.StringAttribute {
+		return schema.StringAttribute{Required: required, Optional: optional, Computed: computed}
+	}
+	num := func(optional, computed bool) schema.Int64Attribute {
+		return schema.Int64Attribute{Optional: optional, Computed: computed}
+	}
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":             str(false, false, true),
+			"name":           str(true, false, false),
+			"lease_end_time": str(false, true, true),
+			"project_id":     str(false, true, true),
+			"endpoint":       str(false, false, true),
+			"ssh_keys":       schema.ListAttribute{ElementType: types.StringType, Optional: true},
+			"state":          str(false, false, true),
+			"sliver_count":   num(false, true),
+			"graph_model":    str(false, false, true),
+			"topology_dot":   str(false, false, true),
+			"ssh_config":     str(false, false, true),
+			"topology": schema.SingleNestedAttribute{
+				Required: true,
+				Attributes: map[string]schema.Attribute{
+					"nodes": schema.ListNestedAttribute{
+						Required: true,
+						NestedObject: schema.NestedAttributeObject{Attributes: map[string]schema.Attribute{
+							"name":                str(true, false, false),
+							"site":                str(true, false, false),
+							"type":                str(false, true, true),
+							"image_ref":           str(false, true, true),
+							"instance_type":       str(false, true, true),
+							"cores":               num(true, true),
+							"ram":                 num(true, true),
+							"disk":                num(true, true),
+							"management_ip":       str(false, false, true),
+							"username":            str(false, false, true),
+							"ssh_command":         str(false, false, true),
+							"post_boot_script":    str(false, true, false),
+							"post_boot_exit_code": num(false, true),
+							"post_boot_output":    str(false, false, true),
+						}},
+					},
+					"links": schema.ListNestedAttribute{
+						Optional: true,
+						NestedObject: schema.NestedAttributeObject{Attributes: map[string]schema.Attribute{
+							"name":   str(true, false, false),
+							"source": str(true, false, false),
+							"target": str(true, false, false),
+						}},
+					},
+					"network_services": schema.ListNestedAttribute{
+						Optional: true,
+						NestedObject: schema.NestedAttributeObject{Attributes: map[string]schema.Attribute{
+							"name":             str(true, false, false),
+							"type":             str(false, true, true),
+							"site":             str(false, true, false),
+							"mirror_port":      str(false, true, false),
+							"mirror_direction": str(false, true, false),
+							"subnet":           str(false, false, true),
+							"gateway":          str(false, false, true),
+							"interfaces": schema.ListNestedAttribute{
+								Required: true,
+								NestedObject: schema.NestedAttributeObject{Attributes: map[string]schema.Attribute{
+									"name":      str(false, true, true),
+									"node":      str(true, false, false),
+									"vlan":      str(false, true, false),
+									"bandwidth": num(true, false),
+									"mac":       str(false, true, false),
+									"ip_addr":   str(false, true, true),
+									"nic_model": str(false, true, true),
+								}},
+							},
+						}},
+					},
+					"facility_ports": schema.ListNestedAttribute{
+						Optional: true,
+						NestedObject: schema.NestedAttributeObject{Attributes: map[string]schema.Attribute{
+							"name":      str(true, false, false),
+							"site":      str(true, false, false),
+							"vlan":      str(false, true, false),
+							"bandwidth": num(true, true),
+							"labels":    schema.MapAttribute{ElementType: types.StringType, Optional: true},
+						}},
+					},
+				},
+			},
+		},
+	}
+}
+
+type tfPlanV0 struct {
+	ID           types.String  `tfsdk:"id"`
+	Name         types.String  `tfsdk:"name"`
+	LeaseEndTime types.String  `tfsdk:"lease_end_time"`
+	SSHKeys      types.List    `tfsdk:"ssh_keys"`
+	Topology     *tfTopologyV0 `tfsdk:"topology"`
+	State        types.String  `tfsdk:"state"`
+	SliverCount  types.Int64   `tfsdk:"sliver_count"`
+	GraphModel   types.String  `tfsdk:"graph_model"`
+	TopologyDOT  types.String  `tfsdk:"topology_dot"`
+	SSHConfig    types.String  `tfsdk:"ssh_config"`
+	ProjectID    types.String  `tfsdk:"project_id"`
+	Endpoint     types.String  `tfsdk:"endpoint"`
+}
+
+type tfTopologyV0 struct {
+	Nodes           []tfNodeV0           `tfsdk:"nodes"`
+	Links           []tfLinkV0           `tfsdk:"links"`
+	FacilityPorts   []tfFacilityPortV0   `tfsdk:"facility_ports"`
+	NetworkServices []tfNetworkServiceV0 `tfsdk:"network_services"`
+}
+
+type tfNodeV0 struct {
+	Name         types.String `tfsdk:"name"`
+	Site         types.String `tfsdk:"site"`
+	Type         types.String `tfsdk:"type"`
+	ImageRef     types.String `tfsdk:"image_ref"`
+	InstanceType types.String `tfsdk:"instance_type"`
+	Cores        types.Int64  `tfsdk:"cores"`
+	RAM          types.Int64  `tfsdk:"ram"`
+	Disk         types.Int64  `tfsdk:"disk"`
+
+	ManagementIP types.String `tfsdk:"management_ip"`
+	Username     types.String `tfsdk:"username"`
+	SSHCommand   types.String `tfsdk:"ssh_command"`
+
+	PostBootScript   types.String `tfsdk:"post_boot_script"`
+	PostBootExitCode types.Int64  `tfsdk:"post_boot_exit_code"`
+	PostBootOutput   types.String `tfsdk:"post_boot_output"`
+}
+
+type tfLinkV0 struct {
+	Name   types.String `tfsdk:"name"`
+	Source types.String `tfsdk:"source"`
+	Target types.String `tfsdk:"target"`
+}
+
+type tfFacilityPortV0 struct {
+	Name      types.String `tfsdk:"name"`
+	Site      types.String `tfsdk:"site"`
+	VLAN      types.String `tfsdk:"vlan"`
+	Bandwidth types.Int64  `tfsdk:"bandwidth"`
+	Labels    types.Map    `tfsdk:"labels"`
+}
+
+type tfNetworkServiceV0 struct {
+	Name       types.String    `tfsdk:"name"`
+	Type       types.String    `tfsdk:"type"`
+	Subnet     types.String    `tfsdk:"subnet"`
+	Gateway    types.String    `tfsdk:"gateway"`
+	Interfaces []tfInterfaceV0 `tfsdk:"interfaces"`
+
+	Site            types.String `tfsdk:"site"`
+	MirrorPort      types.String `tfsdk:"mirror_port"`
+	MirrorDirection types.String `tfsdk:"mirror_direction"`
+}
+
+type tfInterfaceV0 struct {
+	Name      types.String `tfsdk:"name"`
+	Node      types.String `tfsdk:"node"`
+	VLAN      types.String `tfsdk:"vlan"`
+	Bandwidth types.Int64  `tfsdk:"bandwidth"`
+	MAC       types.String `tfsdk:"mac"`
+	IPAddr    types.String `tfsdk:"ip_addr"`
+	NICModel  types.String `tfsdk:"nic_model"`
+}
+
+// upgradeFromV0 keys nodes and links by their former name attribute. Names
+// that are empty or repeated cannot become map keys; such state is rejected
+// rather than silently dropping entries.
+func upgradeFromV0(ctx context.Context, req rframework.UpgradeStateRequest, resp *rframework.UpgradeStateResponse) {
+	var old tfPlanV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &old)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if old.Topology != nil {
+		nodes := make([]string, 0, len(old.Topology.Nodes))
+		for _, n := range old.Topology.Nodes {
+			nodes = append(nodes, toString(n.Name))
+		}
+		links := make([]string, 0, len(old.Topology.Links))
+		for _, l := range old.Topology.Links {
+			links = append(links, toString(l.Name))
+		}
+		if err := errors.Join(uniqueNames("node", nodes), uniqueNames("link", links)); err != nil {
+			resp.Diagnostics.AddError("Cannot upgrade fabric_slice state",
+				fmt.Sprintf("Slice %s: %s\n\nNodes and links are now keyed by name. Fix the names in the configuration, "+
+					"then remove the slice from state and import it again.", toString(old.ID), err))
+			return
+		}
+	}
+
+	tf := TFPlan{
+		ID:           old.ID,
+		Name:         old.Name,
+		LeaseEndTime: old.LeaseEndTime,
+		SSHKeys:      old.SSHKeys,
+		State:        old.State,
+		SliverCount:  old.SliverCount,
+		GraphModel:   old.GraphModel,
+		TopologyDOT:  old.TopologyDOT,
+		SSHConfig:    old.SSHConfig,
+		ProjectID:    old.ProjectID,
+		Endpoint:     old.Endpoint,
+	}
+	if old.Topology != nil {
+		topo := &TFTopology{
+			Nodes: make(map[string]TFNode, len(old.Topology.Nodes)),
+		}
+		for _, n := range old.Topology.Nodes {
+			topo.Nodes[toString(n.Name)] = TFNode{
+				Site:             n.Site,
+				Type:             n.Type,
+				ImageRef:         n.ImageRef,
+				InstanceType:     n.InstanceType,
+				Cores:            n.Cores,
+				RAM:              n.RAM,
+				Disk:             n.Disk,
+				ManagementIP:     n.ManagementIP,
+				Username:         n.Username,
+				SSHCommand:       n.SSHCommand,
+				PostBootScript:   n.PostBootScript,
+				PostBootExitCode: n.PostBootExitCode,
+				PostBootOutput:   n.PostBootOutput,
+			}
+		}
+		if old.Topology.Links != nil {
+			topo.Links = make(map[string]TFLink, len(old.Topology.Links))
+		}
+		for _, l := range old.Topology.Links {
+			topo.Links[toString(l.Name)] = TFLink{Source: l.Source, Target: l.Target}
+		}
+		for _, fp := range old.Topology.FacilityPorts {
+			topo.FacilityPorts = append(topo.FacilityPorts, TFFacilityPort(fp))
+		}
+		for _, ns := range old.Topology.NetworkServices {
+			svc := TFNetworkService{
+				Name:            ns.Name,
+				Type:            ns.Type,
+				Subnet:          ns.Subnet,
+				Gateway:         ns.Gateway,
+				Site:            ns.Site,
+				MirrorPort:      ns.MirrorPort,
+				MirrorDirection: ns.MirrorDirection,
+			}
+			for _, ifc := range ns.Interfaces {
+				svc.Interfaces = append(svc.Interfaces, TFInterface(ifc))
+			}
+			topo.NetworkServices = append(topo.NetworkServices, svc)
+		}
+		tf.Topology = topo
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &tf)...)
+}
+
+func uniqueNames(kind string, names []string) error {
+	var errs []error
+	seen := make(map[string]bool, len(names))
+	for i, name := range names {
+		switch {
+		case name == "":
+			errs = append(errs, fmt.Errorf("%s %d has no name", kind, i))
+		case seen[name]:
+			errs = append(errs, fmt.Errorf("%s name %q is used more than once", kind, name))
+		}
+		seen[name] = true
+	}
+	return errors.Join(errs...)
+}
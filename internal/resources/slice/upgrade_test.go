@@ -0,0 +1,190 @@
+package slice_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/provider"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/resources/slice"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// stateV0 is fabric_slice state as written by the first release: nodes and
+// links as lists, and none of the attributes added since.
+const stateV0 = `{
+  "id": "6b1f2c3a-0000-4000-8000-000000000001",
+  "name": "demo",
+  "lease_end_time": "2025-01-02 03:04:05 +0000",
+  "ssh_keys": ["ssh-ed25519 AAAA test"],
+  "state": "StableOK",
+  "sliver_count": 3,
+  "topology": {
+    "nodes": [
+      {"name": "n2", "site": "RENC", "type": "VM", "image_ref": "default_rocky_8", "instance_type": null,
+       "cores": 4, "ram": 16, "disk": 100},
+      {"name": "n1", "site": "UCSD", "type": "VM", "image_ref": "default_ubuntu_22", "instance_type": "fabric.c2.m8.d10",
+       "cores": 2, "ram": 8, "disk": 10}
+    ],
+    "links": [
+      {"name": "l2", "source": "n2", "target": "n1"},
+      {"name": "l1", "source": "n1", "target": "n2"}
+    ]
+  }
+}`
+
+func upgradeV0(t *testing.T, state string) (*tfprotov6.UpgradeResourceStateResponse, tftypes.Value) {
+	t.Helper()
+	ctx := context.Background()
+	server, err := providerserver.NewProtocol6WithError(provider.New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: "fabric_slice",
+		Version:  0,
+		RawState: &tfprotov6.RawState{JSON: []byte(state)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.UpgradedState == nil {
+		return resp, tftypes.Value{}
+	}
+	raw, err := resp.UpgradedState.Unmarshal(slice.Schema().Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, raw
+}
+
+func TestUpgradeStateV0(t *testing.T) {
+	resp, raw := upgradeV0(t, stateV0)
+	for _, d := range resp.Diagnostics {
+		t.Errorf("diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+	if resp.UpgradedState == nil {
+		t.Fatal("no upgraded state")
+	}
+
+	var tf slice.TFPlan
+	if diags := (tfsdk.State{Schema: slice.Schema(), Raw: raw}).Get(context.Background(), &tf); diags.HasError() {
+		t.Fatalf("decode upgraded state: %v", diags)
+	}
+	if got := tf.ID.ValueString(); got != "6b1f2c3a-0000-4000-8000-000000000001" {
+		t.Errorf("id = %q", got)
+	}
+	if got := tf.LeaseEndTime.ValueString(); got != "2025-01-02 03:04:05 +0000" {
+		t.Errorf("lease_end_time = %q", got)
+	}
+	if got := tf.SliverCount.ValueInt64(); got != 3 {
+		t.Errorf("sliver_count = %d, want 3", got)
+	}
+	if len(tf.SSHKeys.Elements()) != 1 {
+		t.Errorf("ssh_keys = %v", tf.SSHKeys)
+	}
+
+	if len(tf.Topology.Nodes) != 2 {
+		t.Fatalf("nodes = %v, want n1 and n2", tf.Topology.Nodes)
+	}
+	n1, n2 := tf.Topology.Nodes["n1"], tf.Topology.Nodes["n2"]
+	if n1.Site.ValueString() != "UCSD" || n1.Cores.ValueInt64() != 2 || n1.InstanceType.ValueString() != "fabric.c2.m8.d10" {
+		t.Errorf("n1 = %+v", n1)
+	}
+	if n2.Site.ValueString() != "RENC" || n2.RAM.ValueInt64() != 16 || n2.Disk.ValueInt64() != 100 {
+		t.Errorf("n2 = %+v", n2)
+	}
+
+	if len(tf.Topology.Links) != 2 {
+		t.Fatalf("links = %v, want l1 and l2", tf.Topology.Links)
+	}
+	if l := tf.Topology.Links["l1"]; l.Source.ValueString() != "n1" || l.Target.ValueString() != "n2" {
+		t.Errorf("l1 = %+v", l)
+	}
+	if l := tf.Topology.Links["l2"]; l.Source.ValueString() != "n2" || l.Target.ValueString() != "n1" {
+		t.Errorf("l2 = %+v", l)
+	}
+
+	// Everything the first release did not write must come out null, not
+	// zero values the next plan would have to diff away.
+	var fixture map[string]interface{}
+	if err := json.Unmarshal([]byte(stateV0), &fixture); err != nil {
+		t.Fatal(err)
+	}
+	assertOnlyV0Set(t, "", raw, fixture)
+}
+
+// assertOnlyV0Set walks the upgraded value next to the v0 fixture and fails
+// for every attribute that is set but absent (or null) in the fixture. Lists
+// of named objects in the fixture match maps keyed by name in the value.
+func assertOnlyV0Set(t *testing.T, path string, v tftypes.Value, fixture interface{}) {
+	t.Helper()
+	if fixture == nil {
+		if !v.IsNull() {
+			t.Errorf("%s = %v, want null", path, v)
+		}
+		return
+	}
+	if v.IsNull() {
+		return
+	}
+	switch {
+	case v.Type().Is(tftypes.Object{}):
+		var attrs map[string]tftypes.Value
+		if err := v.As(&attrs); err != nil {
+			t.Fatal(err)
+		}
+		obj, _ := fixture.(map[string]interface{})
+		for name, av := range attrs {
+			assertOnlyV0Set(t, strings.TrimPrefix(path+"."+name, "."), av, obj[name])
+		}
+	case v.Type().Is(tftypes.Map{}):
+		var elems map[string]tftypes.Value
+		if err := v.As(&elems); err != nil {
+			t.Fatal(err)
+		}
+		byName := map[string]interface{}{}
+		if list, ok := fixture.([]interface{}); ok {
+			for _, e := range list {
+				if obj, ok := e.(map[string]interface{}); ok {
+					if name, ok := obj["name"].(string); ok {
+						byName[name] = obj
+					}
+				}
+			}
+		}
+		for key, ev := range elems {
+			if byName[key] == nil {
+				t.Errorf("%s[%q] is not in the v0 state", path, key)
+				continue
+			}
+			assertOnlyV0Set(t, path+"["+key+"]", ev, byName[key])
+		}
+	}
+}
+
+func TestUpgradeStateV0DuplicateNames(t *testing.T) {
+	state := strings.Replace(stateV0, `"name": "n2"`, `"name": "n1"`, 1)
+	state = strings.Replace(state, `"name": "l2"`, `"name": ""`, 1)
+
+	resp, _ := upgradeV0(t, state)
+	if resp.UpgradedState != nil {
+		t.Error("state with duplicate node names was upgraded")
+	}
+	if len(resp.Diagnostics) != 1 {
+		t.Fatalf("diagnostics = %+v, want one error", resp.Diagnostics)
+	}
+	d := resp.Diagnostics[0]
+	if d.Severity != tfprotov6.DiagnosticSeverityError || d.Summary != "Cannot upgrade fabric_slice state" {
+		t.Errorf("diagnostic = %s: %s", d.Summary, d.Detail)
+	}
+	for _, want := range []string{`node name "n1" is used more than once`, "link 0 has no name"} {
+		if !strings.Contains(d.Detail, want) {
+			t.Errorf("detail %q does not mention %q", d.Detail, want)
+		}
+	}
+}
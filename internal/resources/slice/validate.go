@@ -0,0 +1,196 @@
+package slice
+
+import (
+	"errors"
+	"fmt"
+	"net"
+)
+
+var serviceTypes = map[string]bool{
+	"L2Bridge":   true,
+	"L2STS":      true,
+	"L2PTP":      true,
+	"FABNetv4":   true,
+	"FABNetv6":   true,
+	"PortMirror": true,
+}
+
+var nicModels = map[string]bool{
+	"NIC_Basic":      true,
+	"NIC_ConnectX_5": true,
+	"NIC_ConnectX_6": true,
+}
+
+// dedicatedNIC reports whether a NIC model is a dedicated SmartNIC rather
+// than a shared virtual function.
+func dedicatedNIC(model string) bool {
+	return model == "NIC_ConnectX_5" || model == "NIC_ConnectX_6"
+}
+
+// validatePlan checks cross-references inside a normalized plan that the
+// schema alone cannot express.
+func validatePlan(p Plan) error {
+	var errs []error
+
+	names := map[string]string{}
+	claim := func(kind, name string) {
+		if prev, ok := names[name]; ok {
+			errs = append(errs, fmt.Errorf("%s %q: name already used by a %s", kind, name, prev))
+			return
+		}
+		names[name] = kind
+	}
+	sites := map[string]string{}
+	switchSites := map[string]string{}
+	for _, n := range p.Topology.Nodes {
+		sites[n.Name] = n.Site
+		switch n.Type {
+		case "VM":
+			claim("node", n.Name)
+		case "Switch":
+			claim("switch", n.Name)
+			errs = append(errs, validateSwitch(n)...)
+			if other, ok := switchSites[n.Site]; ok {
+				errs = append(errs, fmt.Errorf("switch %q: site %s already has P4 switch %q", n.Name, n.Site, other))
+			}
+			switchSites[n.Site] = n.Name
+		default:
+			claim("node", n.Name)
+			errs = append(errs, fmt.Errorf("node %q: unsupported type %q (expected VM or Switch)", n.Name, n.Type))
+		}
+	}
+	for _, fp := range p.Topology.FacilityPorts {
+		claim("facility port", fp.Name)
+		sites[fp.Name] = fp.Site
+	}
+	attachable := func(name string) bool {
+		kind := names[name]
+		return kind == "node" || kind == "switch" || kind == "facility port"
+	}
+
+	for _, l := range p.Topology.Links {
+		for _, end := range []string{l.Source, l.Target} {
+			if !attachable(end) {
+				errs = append(errs, fmt.Errorf("link %q: unknown node, switch or facility port %q", l.Name, end))
+			}
+		}
+	}
+
+	for _, ns := range p.Topology.NetworkServices {
+		claim("network service", ns.Name)
+		if !serviceTypes[ns.Type] {
+			errs = append(errs, fmt.Errorf("network service %q: unsupported type %q", ns.Name, ns.Type))
+		}
+		errs = append(errs, validateServiceInterfaces(ns, names, sites)...)
+		for _, ifc := range ns.Interfaces {
+			claim("interface", ifc.Name)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateServiceInterfaces(ns NetworkServicePlan, names, sites map[string]string) []error {
+	var errs []error
+	switch ns.Type {
+	case "L2PTP", "L2STS":
+		if len(ns.Interfaces) != 2 {
+			errs = append(errs, fmt.Errorf("network service %q: %s needs exactly 2 interfaces, got %d", ns.Name, ns.Type, len(ns.Interfaces)))
+		}
+	case "L2Bridge", "FABNetv4", "FABNetv6":
+		site := commonSite(ns.Interfaces, sites)
+		if site == "" && len(ns.Interfaces) > 0 {
+			errs = append(errs, fmt.Errorf("network service %q: all %s interfaces must be at the same site", ns.Name, ns.Type))
+		} else if ns.Site != "" && site != "" && ns.Site != site {
+			errs = append(errs, fmt.Errorf("network service %q: site %q does not match its interfaces' site %q", ns.Name, ns.Site, site))
+		}
+	case "PortMirror":
+		errs = append(errs, validatePortMirror(ns, names, sites)...)
+	}
+	if ns.Type != "PortMirror" && (ns.MirrorPort != "" || ns.MirrorDirection != "") {
+		errs = append(errs, fmt.Errorf("network service %q: mirror_port and mirror_direction only apply to PortMirror", ns.Name))
+	}
+
+	for _, ifc := range ns.Interfaces {
+		switch names[ifc.Node] {
+		case "node":
+			if !nicModels[ifc.NICModel] {
+				errs = append(errs, fmt.Errorf("interface %q: unsupported nic_model %q", ifc.Name, ifc.NICModel))
+			}
+		case "switch":
+			if ifc.NICModel != "" {
+				errs = append(errs, fmt.Errorf("interface %q: switch ports have no nic_model", ifc.Name))
+			}
+		case "facility port":
+			if ifc.VLAN != "" || ifc.Bandwidth != 0 || ifc.MAC != "" || ifc.IPAddr != "" || ifc.NICModel != "" {
+				errs = append(errs, fmt.Errorf("interface %q: interfaces on facility port %q take their settings from the port", ifc.Name, ifc.Node))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("interface %q: unknown node, switch or facility port %q", ifc.Name, ifc.Node))
+		}
+		if ifc.IPAddr != "" && net.ParseIP(ifc.IPAddr) == nil {
+			errs = append(errs, fmt.Errorf("interface %q: invalid ip_addr %q", ifc.Name, ifc.IPAddr))
+		}
+		if ifc.MAC != "" {
+			if _, err := net.ParseMAC(ifc.MAC); err != nil {
+				errs = append(errs, fmt.Errorf("interface %q: invalid mac %q", ifc.Name, ifc.MAC))
+			}
+		}
+	}
+	return errs
+}
+
+// validatePortMirror checks that a PortMirror service mirrors an advertised
+// port into exactly one dedicated SmartNIC on a node at the service's site.
+func validatePortMirror(ns NetworkServicePlan, names, sites map[string]string) []error {
+	var errs []error
+	if ns.MirrorPort == "" {
+		errs = append(errs, fmt.Errorf("network service %q: PortMirror requires mirror_port", ns.Name))
+	}
+	if ns.Site == "" {
+		errs = append(errs, fmt.Errorf("network service %q: PortMirror requires site", ns.Name))
+	}
+	switch ns.MirrorDirection {
+	case "", "both", "rx", "tx":
+	default:
+		errs = append(errs, fmt.Errorf("network service %q: mirror_direction must be both, rx or tx", ns.Name))
+	}
+	if len(ns.Interfaces) != 1 {
+		errs = append(errs, fmt.Errorf("network service %q: PortMirror needs exactly 1 target interface, got %d", ns.Name, len(ns.Interfaces)))
+		return errs
+	}
+
+	target := ns.Interfaces[0]
+	if names[target.Node] != "node" {
+		return append(errs, fmt.Errorf("network service %q: mirror target %q must be an interface on a VM node", ns.Name, target.Name))
+	}
+	if !dedicatedNIC(target.NICModel) {
+		errs = append(errs, fmt.Errorf("network service %q: mirror target %q must use a dedicated SmartNIC (NIC_ConnectX_5 or NIC_ConnectX_6), got %q", ns.Name, target.Name, target.NICModel))
+	}
+	if ns.Site != "" && sites[target.Node] != ns.Site {
+		errs = append(errs, fmt.Errorf("network service %q: mirror target node %q is at %q, not %q", ns.Name, target.Node, sites[target.Node], ns.Site))
+	}
+	return errs
+}
+
+// validateSwitch rejects VM-only attributes on P4 switch nodes.
+func validateSwitch(n NodePlan) []error {
+	var errs []error
+	vmOnly := []struct {
+		attr string
+		set  bool
+	}{
+		{"image_ref", n.ImageRef != ""},
+		{"instance_type", n.InstanceType != ""},
+		{"cores", n.Cores != 0},
+		{"ram", n.RAM != 0},
+		{"disk", n.Disk != 0},
+		{"post_boot_script", n.PostBootScript != ""},
+	}
+	for _, f := range vmOnly {
+		if f.set {
+			errs = append(errs, fmt.Errorf("switch %q: %s is only valid on VM nodes", n.Name, f.attr))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,18 @@
+package slicenode
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type Model struct {
+	ID           types.String `tfsdk:"id"`
+	SliceID      types.String `tfsdk:"slice_id"`
+	Name         types.String `tfsdk:"name"`
+	Site         types.String `tfsdk:"site"`
+	ImageRef     types.String `tfsdk:"image_ref"`
+	InstanceType types.String `tfsdk:"instance_type"`
+	Cores        types.Int64  `tfsdk:"cores"`
+	RAM          types.Int64  `tfsdk:"ram"`
+	Disk         types.Int64  `tfsdk:"disk"`
+	ManagementIP types.String `tfsdk:"management_ip"`
+	ProjectID    types.String `tfsdk:"project_id"`
+	Endpoint     types.String `tfsdk:"endpoint"`
+}
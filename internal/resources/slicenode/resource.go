@@ -0,0 +1,253 @@
+package slicenode
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/resources/slice"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/runtime"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/services"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/topology"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	rframework "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ rframework.Resource = &Resource{}
+var _ rframework.ResourceWithImportState = &Resource{}
+
+type Resource struct {
+	deps *runtime.Deps
+}
+
+func New() rframework.Resource { return &Resource{} }
+
+func (r *Resource) Metadata(_ context.Context, req rframework.MetadataRequest, resp *rframework.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_slice_node"
+}
+
+func (r *Resource) Schema(_ context.Context, _ rframework.SchemaRequest, resp *rframework.SchemaResponse) {
+	resp.Schema = Schema()
+}
+
+func (r *Resource) Configure(_ context.Context, req rframework.ConfigureRequest, resp *rframework.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d, ok := req.ProviderData.(*runtime.Deps)
+	if !ok {
+		resp.Diagnostics.AddError("Internal error", fmt.Sprintf("unexpected provider deps type %T", req.ProviderData))
+		return
+	}
+	r.deps = d
+}
+
+func (r *Resource) Create(ctx context.Context, req rframework.CreateRequest, resp *rframework.CreateResponse) {
+	var plan Model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sliceID, name := plan.SliceID.ValueString(), plan.Name.ValueString()
+
+	node := slice.NodePlan{
+		Name:         name,
+		Site:         plan.Site.ValueString(),
+		Type:         "VM",
+		ImageRef:     plan.ImageRef.ValueString(),
+		InstanceType: plan.InstanceType.ValueString(),
+		Cores:        plan.Cores.ValueInt64(),
+		RAM:          plan.RAM.ValueInt64(),
+		Disk:         plan.Disk.ValueInt64(),
+	}
+
+	slices, err := r.deps.SlicesFor(ctx, plan.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project", err.Error())
+		return
+	}
+	sl, err := slices.Edit(ctx, sliceID, func(m *topology.Model) error {
+		if _, ok := m.FindByName("NetworkNode", name); ok {
+			return fmt.Errorf("slice %s already has a node named %q", sliceID, name)
+		}
+		pNorm, graph, err := slice.BuildRequest(slice.Plan{Topology: slice.TopologyPlan{Nodes: []slice.NodePlan{node}}}, m.GraphID())
+		if err != nil {
+			return err
+		}
+		node = pNorm.Topology.Nodes[0]
+		m.Merge(topology.Decode(graph))
+		return nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Add node failed", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(sliceID + "/" + name)
+	plan.ImageRef = types.StringValue(node.ImageRef)
+	plan.InstanceType = types.StringValue(node.InstanceType)
+	plan.Cores = types.Int64Value(node.Cores)
+	plan.RAM = types.Int64Value(node.RAM)
+	plan.Disk = types.Int64Value(node.Disk)
+	plan.ManagementIP = managementIP(sl.Model, sl.ModelFormat, name)
+	plan.ProjectID = slice.SliceProject(r.deps, plan.ProjectID, sl)
+	plan.Endpoint = types.StringValue(r.deps.Endpoint)
+
+	// The node was submitted either way; record it so a failed modify
+	// taints it instead of leaking it into the slice.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if !services.ModifySucceeded(sl.State) {
+		resp.Diagnostics.AddError("Add node failed",
+			fmt.Sprintf("Slice %s settled in state %s after adding node %q.", sliceID, sl.State, name))
+	}
+}
+
+func (r *Resource) Read(ctx context.Context, req rframework.ReadRequest, resp *rframework.ReadResponse) {
+	var state Model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sliceID, name := state.SliceID.ValueString(), state.Name.ValueString()
+
+	if !slice.CheckEndpoint(r.deps, sliceID, state.Endpoint, &resp.Diagnostics) {
+		return
+	}
+	slices, err := slice.StateSlices(ctx, r.deps, state.ProjectID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project", err.Error())
+		return
+	}
+	sl, err := slices.Get(ctx, sliceID)
+	if err != nil {
+		if services.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Read slice failed", err.Error())
+		return
+	}
+	if services.IsGone(sl.State) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	m, err := topology.ParseModelFormat(sl.ModelFormat, sl.Model)
+	if err != nil {
+		resp.Diagnostics.AddError("Could not decode slice model", err.Error())
+		return
+	}
+	v, ok := m.FindByName("NetworkNode", name)
+	if !ok {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ID = types.StringValue(sliceID + "/" + name)
+	state.ProjectID = slice.SliceProject(r.deps, state.ProjectID, sl)
+	state.Endpoint = types.StringValue(r.deps.Endpoint) // imported or older state
+	if site := v.Props["Site"]; site != "" {
+		state.Site = types.StringValue(site)
+	}
+	if image := v.Props["ImageRef"]; image != "" {
+		state.ImageRef = types.StringValue(image)
+	}
+	var hints struct {
+		InstanceType string `json:"instance_type"`
+	}
+	if v.JSONProp("CapacityHints", &hints) == nil && hints.InstanceType != "" {
+		state.InstanceType = types.StringValue(hints.InstanceType)
+	}
+	var caps struct {
+		Core int64 `json:"core"`
+		RAM  int64 `json:"ram"`
+		Disk int64 `json:"disk"`
+	}
+	if v.JSONProp("Capacities", &caps) == nil {
+		setSize(&state.Cores, caps.Core)
+		setSize(&state.RAM, caps.RAM)
+		setSize(&state.Disk, caps.Disk)
+	}
+	state.ManagementIP = types.StringNull()
+	if ip := v.ManagementIP(); ip != "" {
+		state.ManagementIP = types.StringValue(ip)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *Resource) Update(_ context.Context, _ rframework.UpdateRequest, resp *rframework.UpdateResponse) {
+	resp.Diagnostics.AddError("Update not supported", "Slice nodes are replaced rather than updated.")
+}
+
+func (r *Resource) Delete(ctx context.Context, req rframework.DeleteRequest, resp *rframework.DeleteResponse) {
+	var state Model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sliceID, name := state.SliceID.ValueString(), state.Name.ValueString()
+
+	if !slice.CheckEndpoint(r.deps, sliceID, state.Endpoint, &resp.Diagnostics) {
+		return
+	}
+	slices, err := slice.StateSlices(ctx, r.deps, state.ProjectID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project", err.Error())
+		return
+	}
+	removed := false
+	sl, err := slices.Edit(ctx, sliceID, func(m *topology.Model) error {
+		v, ok := m.FindByName("NetworkNode", name)
+		if !ok {
+			return services.ErrNoChange
+		}
+		m.Remove(v.ID)
+		removed = true
+		return nil
+	})
+	if err != nil {
+		if services.IsNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Remove node failed", err.Error())
+		return
+	}
+	if removed && !services.ModifySucceeded(sl.State) {
+		resp.Diagnostics.AddError("Remove node failed",
+			fmt.Sprintf("Slice %s settled in state %s after removing node %q.", sliceID, sl.State, name))
+	}
+}
+
+// ImportState takes "<slice_id>/<name>".
+func (r *Resource) ImportState(ctx context.Context, req rframework.ImportStateRequest, resp *rframework.ImportStateResponse) {
+	sliceID, name, ok := strings.Cut(req.ID, "/")
+	if !ok || sliceID == "" || name == "" {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("Expected <slice_id>/<name>, got %q.", req.ID))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("slice_id"), sliceID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
+
+// managementIP looks the node up in a slice model; it is null until the
+// orchestrator has assigned one.
+func managementIP(model, format, name string) types.String {
+	m, err := topology.ParseModelFormat(format, model)
+	if err != nil {
+		return types.StringNull()
+	}
+	if v, ok := m.FindByName("NetworkNode", name); ok {
+		if ip := v.ManagementIP(); ip != "" {
+			return types.StringValue(ip)
+		}
+	}
+	return types.StringNull()
+}
+
+func setSize(dst *types.Int64, v int64) {
+	if v != 0 {
+		*dst = types.Int64Value(v)
+	}
+}
@@ -0,0 +1,86 @@
+package slicenode
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+)
+
+func Schema() schema.Schema {
+	replace := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+	replaceDefault := []planmodifier.String{stringplanmodifier.RequiresReplace(), stringplanmodifier.UseStateForUnknown()}
+	replaceSize := []planmodifier.Int64{int64planmodifier.RequiresReplace(), int64planmodifier.UseStateForUnknown()}
+
+	return schema.Schema{
+		MarkdownDescription: "Adds a VM node to an existing `fabric_slice` through the slice modify API. Nodes cannot be resized in place; any change replaces the node.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "`<slice_id>/<name>`.",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"slice_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the slice the node belongs to.",
+				Required:            true,
+				PlanModifiers:       replace,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Node name, unique within the slice.",
+				Required:            true,
+				PlanModifiers:       replace,
+			},
+			"site": schema.StringAttribute{
+				MarkdownDescription: "FABRIC site.",
+				Required:            true,
+				PlanModifiers:       replace,
+			},
+			"image_ref": schema.StringAttribute{
+				MarkdownDescription: "Image. Defaults to `default_rocky_8,qcow2`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers:       replaceDefault,
+			},
+			"instance_type": schema.StringAttribute{
+				MarkdownDescription: "Instance type hint. Defaults to `fabric.c2.m2.d10`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers:       replaceDefault,
+			},
+			"cores": schema.Int64Attribute{
+				MarkdownDescription: "CPU cores. Defaults to 2.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers:       replaceSize,
+			},
+			"ram": schema.Int64Attribute{
+				MarkdownDescription: "RAM in GB. Defaults to 2.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers:       replaceSize,
+			},
+			"disk": schema.Int64Attribute{
+				MarkdownDescription: "Disk in GB. Defaults to 10.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers:       replaceSize,
+			},
+			"management_ip": schema.StringAttribute{
+				MarkdownDescription: "Management IP assigned once the node is active.",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "FABRIC project owning the slice. Defaults to the provider's `project_id`; a different project needs the provider's `refresh_token`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers:       replaceDefault,
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "Orchestrator endpoint the node was added through. Providers configured for another endpoint refuse to manage the node.",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+		},
+	}
+}
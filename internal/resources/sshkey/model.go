@@ -0,0 +1,14 @@
+package sshkey
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type Model struct {
+	ID          types.String `tfsdk:"id"`
+	KeyType     types.String `tfsdk:"key_type"`
+	Comment     types.String `tfsdk:"comment"`
+	Description types.String `tfsdk:"description"`
+	PublicKey   types.String `tfsdk:"public_key"`
+	PrivateKey  types.String `tfsdk:"private_key"`
+	Fingerprint types.String `tfsdk:"fingerprint"`
+	ExpiresOn   types.String `tfsdk:"expires_on"`
+}
@@ -0,0 +1,132 @@
+package sshkey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/clients/coreapi"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/runtime"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	rframework "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ rframework.Resource = &Resource{}
+var _ rframework.ResourceWithImportState = &Resource{}
+
+type Resource struct {
+	deps *runtime.Deps
+}
+
+func New() rframework.Resource { return &Resource{} }
+
+func (r *Resource) Metadata(_ context.Context, req rframework.MetadataRequest, resp *rframework.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ssh_key"
+}
+
+func (r *Resource) Schema(_ context.Context, _ rframework.SchemaRequest, resp *rframework.SchemaResponse) {
+	resp.Schema = Schema()
+}
+
+func (r *Resource) Configure(_ context.Context, req rframework.ConfigureRequest, resp *rframework.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d, ok := req.ProviderData.(*runtime.Deps)
+	if !ok {
+		resp.Diagnostics.AddError("Internal error", fmt.Sprintf("unexpected provider deps type %T", req.ProviderData))
+		return
+	}
+	r.deps = d
+}
+
+func (r *Resource) Create(ctx context.Context, req rframework.CreateRequest, resp *rframework.CreateResponse) {
+	var plan Model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, err := r.deps.SSHKeys.Create(ctx, coreapi.CreateSSHKeyRequest{
+		Comment:     plan.Comment.ValueString(),
+		Description: plan.Description.ValueString(),
+		KeyType:     plan.KeyType.ValueString(),
+		PublicKey:   plan.PublicKey.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Create SSH key failed", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(key.UUID)
+	if plan.PublicKey.IsNull() || plan.PublicKey.IsUnknown() {
+		plan.PublicKey = types.StringValue(key.PublicKey)
+	}
+	plan.PrivateKey = types.StringNull()
+	if key.PrivateKey != "" {
+		plan.PrivateKey = types.StringValue(key.PrivateKey)
+	}
+	plan.Fingerprint = types.StringValue(key.Fingerprint)
+	plan.ExpiresOn = types.StringValue(key.ExpiresOn)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *Resource) Read(ctx context.Context, req rframework.ReadRequest, resp *rframework.ReadResponse) {
+	var state Model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, err := r.deps.SSHKeys.Get(ctx, state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, coreapi.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Read SSH key failed", err.Error())
+		return
+	}
+
+	// The private key is only known at creation; keep whatever state holds.
+	state.KeyType = types.StringValue(key.KeyType)
+	state.Comment = types.StringValue(key.Comment)
+	if key.Description != "" || !state.Description.IsNull() {
+		state.Description = types.StringValue(key.Description)
+	}
+	if state.PublicKey.IsNull() || state.PublicKey.IsUnknown() {
+		state.PublicKey = types.StringValue(key.PublicKey)
+	}
+	if state.PrivateKey.IsUnknown() {
+		state.PrivateKey = types.StringNull()
+	}
+	state.Fingerprint = types.StringValue(key.Fingerprint)
+	state.ExpiresOn = types.StringValue(key.ExpiresOn)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *Resource) Update(_ context.Context, _ rframework.UpdateRequest, resp *rframework.UpdateResponse) {
+	resp.Diagnostics.AddError("Update not supported", "SSH keys are replaced rather than updated.")
+}
+
+func (r *Resource) Delete(ctx context.Context, req rframework.DeleteRequest, resp *rframework.DeleteResponse) {
+	var state Model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state.ID.ValueString() == "" {
+		return
+	}
+	err := r.deps.SSHKeys.Delete(ctx, state.ID.ValueString())
+	if err != nil && !errors.Is(err, coreapi.ErrNotFound) {
+		resp.Diagnostics.AddError("Delete SSH key failed", err.Error())
+	}
+}
+
+func (r *Resource) ImportState(ctx context.Context, req rframework.ImportStateRequest, resp *rframework.ImportStateResponse) {
+	rframework.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
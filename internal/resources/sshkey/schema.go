@@ -0,0 +1,60 @@
+package sshkey
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+)
+
+func Schema() schema.Schema {
+	replace := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+	keep := []planmodifier.String{stringplanmodifier.UseStateForUnknown()}
+
+	return schema.Schema{
+		MarkdownDescription: "Manages a FABRIC bastion or sliver SSH key through the Core API. Keys cannot be changed in place; any change replaces the key.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Key UUID.",
+				Computed:            true,
+				PlanModifiers:       keep,
+			},
+			"key_type": schema.StringAttribute{
+				MarkdownDescription: "`bastion` or `sliver`.",
+				Required:            true,
+				PlanModifiers:       replace,
+			},
+			"comment": schema.StringAttribute{
+				MarkdownDescription: "Key comment (appended to the public key).",
+				Required:            true,
+				PlanModifiers:       replace,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Key description.",
+				Optional:            true,
+				PlanModifiers:       replace,
+			},
+			"public_key": schema.StringAttribute{
+				MarkdownDescription: "OpenSSH public key to register. When omitted the Core API generates a key pair.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers:       append([]planmodifier.String{stringplanmodifier.RequiresReplace()}, keep...),
+			},
+			"private_key": schema.StringAttribute{
+				MarkdownDescription: "Generated OpenSSH private key. Only set when the key pair was generated by the Core API.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers:       keep,
+			},
+			"fingerprint": schema.StringAttribute{
+				MarkdownDescription: "Key fingerprint.",
+				Computed:            true,
+				PlanModifiers:       keep,
+			},
+			"expires_on": schema.StringAttribute{
+				MarkdownDescription: "When the Core API expires the key.",
+				Computed:            true,
+				PlanModifiers:       keep,
+			},
+		},
+	}
+}
@@ -1,10 +1,68 @@
 package runtime
 
-import "github.com/csc478-wcu/terraform-provider-fabric/internal/services"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/services"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/utils"
+)
 
 type Deps struct {
 	Slices        services.SlicesService
 	Resources     services.ResourcesService
+	Tokens        services.TokensService
+	SSHKeys       services.SSHKeysService
+	Exec          services.ExecService // nil without a slice private key
 	DefaultSSHKey string
 	Endpoint      string
+	RefreshToken  string
+	ProjectID     string
+
+	BastionHost     string
+	BastionUsername string
+
+	// NewSlices builds a slices service for another project, given a token
+	// minted for it and the project ID.
+	NewSlices func(token, projectID string) services.SlicesService
+
+	// SliceLocks queues operations on the same slice ID. It is shared with
+	// every slices service (including NewSlices ones), which lock it around
+	// modify, renew and delete; resources chaining several calls that must not
+	// interleave take it with LockContext and pass the context on.
+	SliceLocks *utils.KeyedMutex
+
+	mu            sync.Mutex
+	projectSlices map[string]services.SlicesService
+}
+
+// SlicesFor returns the slices service for projectID. The provider's own
+// project (or an empty ID) uses Slices; other projects get a service
+// authenticated with a token minted from the refresh token for that
+// project, cached for the life of the provider.
+func (d *Deps) SlicesFor(ctx context.Context, projectID string) (services.SlicesService, error) {
+	if projectID == "" || projectID == d.ProjectID {
+		return d.Slices, nil
+	}
+	if d.RefreshToken == "" || d.NewSlices == nil {
+		return nil, errors.New("using a project other than the provider's requires the provider 'refresh_token'")
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if s, ok := d.projectSlices[projectID]; ok {
+		return s, nil
+	}
+	tok, err := d.Tokens.Refresh(ctx, d.RefreshToken, projectID, "")
+	if err != nil {
+		return nil, fmt.Errorf("token for project %s: %w", projectID, err)
+	}
+	if d.projectSlices == nil {
+		d.projectSlices = map[string]services.SlicesService{}
+	}
+	s := d.NewSlices(tok.IDToken, projectID)
+	d.projectSlices[projectID] = s
+	return s, nil
 }
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/clients/sshexec"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// SSHReadyTimeout bounds how long RunScript retries connecting to a node
+// whose sshd is not up yet. StableOK is reported before the VM finishes
+// booting. Only connection failures are retried; authentication or host
+// key errors fail at once.
+var SSHReadyTimeout = 5 * time.Minute
+
+// maxScriptOutput is how much of a script's output is kept in state.
+const maxScriptOutput = 4096
+
+type ExecService interface {
+	RunScript(ctx context.Context, host, user, script string) (sshexec.Result, error)
+}
+
+type execService struct{ ssh sshexec.Client }
+
+func NewExecService(c sshexec.Client) ExecService { return &execService{ssh: c} }
+
+func (s *execService) RunScript(ctx context.Context, host, user, script string) (sshexec.Result, error) {
+	ctx = logCtx(ctx)
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Running post-boot script", map[string]interface{}{"host": host, "user": user})
+	tflog.SubsystemTrace(ctx, LogSubsystem, "Post-boot script", map[string]interface{}{"script": script})
+
+	deadline := time.Now().Add(SSHReadyTimeout)
+	for {
+		res, err := s.ssh.RunScript(ctx, host, user, script)
+		if err == nil {
+			res.Output = trimOutput(res.Output)
+			tflog.SubsystemDebug(ctx, LogSubsystem, "Post-boot script finished", map[string]interface{}{"host": host, "exit_code": res.ExitCode})
+			tflog.SubsystemTrace(ctx, LogSubsystem, "Post-boot script output", map[string]interface{}{"host": host, "output": res.Output})
+			return res, nil
+		}
+		var connErr *sshexec.ConnectError
+		if !errors.As(err, &connErr) {
+			return sshexec.Result{}, err
+		}
+		tflog.SubsystemDebug(ctx, LogSubsystem, "Node not reachable yet", map[string]interface{}{"host": host, "error": err.Error()})
+		if time.Now().After(deadline) {
+			return sshexec.Result{}, fmt.Errorf("node %s not reachable after %s: %w", host, SSHReadyTimeout, err)
+		}
+		select {
+		case <-ctx.Done():
+			return sshexec.Result{}, ctx.Err()
+		case <-time.After(PollInterval):
+		}
+	}
+}
+
+// trimOutput strips surrounding whitespace and keeps the tail of long
+// output, where errors usually are.
+func trimOutput(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) <= maxScriptOutput {
+		return s
+	}
+	return "..." + strings.ToValidUTF8(s[len(s)-maxScriptOutput:], "")
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/clients/sshexec"
+)
+
+// fakeSSH fails with errs in turn, then runs the script.
+type fakeSSH struct {
+	errs  []error
+	calls int
+}
+
+func (f *fakeSSH) RunScript(ctx context.Context, host, user, script string) (sshexec.Result, error) {
+	f.calls++
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return sshexec.Result{}, err
+	}
+	return sshexec.Result{ExitCode: 0, Output: "  done\n"}, nil
+}
+
+func TestExecServiceRetries(t *testing.T) {
+	defer func(d time.Duration) { PollInterval = d }(PollInterval)
+	PollInterval = time.Millisecond
+
+	refused := &sshexec.ConnectError{Addr: "10.0.0.1:22", Err: syscall.ECONNREFUSED}
+	authFailed := errors.New("ssh handshake with 10.0.0.1:22: ssh: unable to authenticate")
+
+	for _, tc := range []struct {
+		name      string
+		errs      []error
+		wantCalls int
+		wantErr   error
+	}{
+		{"connection refused is retried", []error{refused, refused}, 3, nil},
+		{"authentication failure is not", []error{authFailed, refused}, 1, authFailed},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ssh := &fakeSSH{errs: tc.errs}
+			res, err := NewExecService(ssh).RunScript(context.Background(), "10.0.0.1", "rocky", "true")
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tc.wantErr)
+			}
+			if ssh.calls != tc.wantCalls {
+				t.Errorf("%d attempts, want %d", ssh.calls, tc.wantCalls)
+			}
+			if err == nil && res.Output != "done" {
+				t.Errorf("output = %q, want trimmed", res.Output)
+			}
+		})
+	}
+}
+
+func TestExecServiceGivesUp(t *testing.T) {
+	defer func(d, r time.Duration) { PollInterval, SSHReadyTimeout = d, r }(PollInterval, SSHReadyTimeout)
+	PollInterval, SSHReadyTimeout = time.Millisecond, 20*time.Millisecond
+
+	refused := &sshexec.ConnectError{Addr: "10.0.0.1:22", Err: syscall.ECONNREFUSED}
+	errs := make([]error, 1000)
+	for i := range errs {
+		errs[i] = refused
+	}
+	_, err := NewExecService(&fakeSSH{errs: errs}).RunScript(context.Background(), "10.0.0.1", "rocky", "true")
+	if !errors.Is(err, syscall.ECONNREFUSED) {
+		t.Fatalf("error = %v, want the last connection error", err)
+	}
+}
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// LogSubsystem is the tflog subsystem for slice lifecycle and SSH activity
+// (TF_LOG_PROVIDER_FABRIC_SERVICES=trace).
+const LogSubsystem = "services"
+
+var sshKeyRegex = regexp.MustCompile(`(ssh-(rsa|dss|ed25519)|ecdsa-sha2-nistp\d+|sk-[a-z0-9@.-]+)\s+[A-Za-z0-9+/=]+`)
+
+func logCtx(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, LogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_FABRIC", LogSubsystem))
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, LogSubsystem, "refresh_token", "id_token")
+	return tflog.SubsystemMaskLogRegexes(ctx, LogSubsystem, sshKeyRegex)
+}
@@ -2,12 +2,15 @@ package services
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/csc478-wcu/terraform-provider-fabric/internal/clients/orchestrator"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/topology"
 )
 
 type ResourcesService interface {
 	List(ctx context.Context, level *int32, includes, excludes []string) ([]string, error)
+	FacilityPorts(ctx context.Context) ([]topology.AdvertisedFacilityPort, error)
 }
 
 type resourcesService struct{ orc orchestrator.Client }
@@ -19,3 +22,20 @@ func NewResourcesService(orc orchestrator.Client) ResourcesService {
 func (s *resourcesService) List(ctx context.Context, level *int32, includes, excludes []string) ([]string, error) {
 	return s.orc.ListResources(ctx, level, includes, excludes)
 }
+
+func (s *resourcesService) FacilityPorts(ctx context.Context) ([]topology.AdvertisedFacilityPort, error) {
+	level := int32(1)
+	models, err := s.orc.ListResources(ctx, &level, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var out []topology.AdvertisedFacilityPort
+	for _, raw := range models {
+		m, err := topology.ParseModel(raw)
+		if err != nil {
+			return nil, fmt.Errorf("decode advertisement: %w", err)
+		}
+		out = append(out, m.FacilityPorts()...)
+	}
+	return out, nil
+}
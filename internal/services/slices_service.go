@@ -2,41 +2,241 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/csc478-wcu/terraform-provider-fabric/internal/clients/orchestrator"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/topology"
 	"github.com/csc478-wcu/terraform-provider-fabric/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// PollInterval is how often slice state is re-read while waiting.
+var PollInterval = 10 * time.Second
+
+// ActiveStates are the slice states that hold on to the slice's name.
+// Closing slices keep it too, until they are Dead.
+var ActiveStates = []string{
+	"Nascent", "Configuring", "StableOK", "StableError",
+	"Modifying", "ModifyOK", "ModifyError", "AllocatedOK", "AllocatedError",
+}
+
+// ErrNoChange is returned by an Edit callback to leave the slice untouched.
+var ErrNoChange = errors.New("no change")
+
 type SlicesService interface {
 	Create(ctx context.Context, name, leaseRFC3339, graphXML string, sshKeys []string) (id, state string, slivers int, leaseFinal string, err error)
-	Get(ctx context.Context, id string) (name, state string, err error)
+	Get(ctx context.Context, id string) (orchestrator.Slice, error)
+	WaitStable(ctx context.Context, id string) (orchestrator.Slice, error)
 	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, name string, states []string) ([]orchestrator.Slice, error)
+	Modify(ctx context.Context, id, graphXML string) (orchestrator.Slice, error)
+	Edit(ctx context.Context, id string, fn func(*topology.Model) error) (orchestrator.Slice, error)
+	Renew(ctx context.Context, id, leaseRFC3339 string) (leaseFinal string, err error)
+	Slivers(ctx context.Context, id string) ([]orchestrator.Sliver, error)
 }
 
-type slicesService struct{ orc orchestrator.Client }
+type slicesService struct {
+	orc   orchestrator.Client
+	locks *utils.KeyedMutex
+}
 
-func NewSlicesService(orc orchestrator.Client) SlicesService { return &slicesService{orc: orc} }
+// NewSlicesService wraps orc. Modify, Edit, Renew and Delete hold locks' key for
+// the slice ID, so operations on one slice run one at a time while other
+// slices proceed; a nil locks gives the service its own set.
+func NewSlicesService(orc orchestrator.Client, locks *utils.KeyedMutex) SlicesService {
+	if locks == nil {
+		locks = &utils.KeyedMutex{}
+	}
+	return &slicesService{orc: orc, locks: locks}
+}
 
 func (s *slicesService) Create(ctx context.Context, name, leaseRFC3339, xml string, keys []string) (string, string, int, string, error) {
 	lease, err := utils.NormalizeLease(leaseRFC3339)
 	if err != nil {
 		return "", "", 0, "", err
 	}
+	ctx = logCtx(ctx)
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Creating slice", map[string]interface{}{
+		"name": name, "lease_end_time": lease, "ssh_keys": len(keys),
+	})
+	tflog.SubsystemTrace(ctx, LogSubsystem, "Slice request graph", map[string]interface{}{"graphml": xml})
+
 	id, state, slivers, err := s.orc.CreateSlice(ctx, name, lease, xml, keys)
+	if err == nil {
+		tflog.SubsystemDebug(ctx, LogSubsystem, "Created slice", map[string]interface{}{
+			"slice_id": id, "state": state, "slivers": slivers,
+		})
+	}
 	return id, state, slivers, lease, err
 }
 
-func (s *slicesService) Get(ctx context.Context, id string) (string, string, error) {
+func (s *slicesService) Get(ctx context.Context, id string) (orchestrator.Slice, error) {
 	return s.orc.GetSlice(ctx, id)
 }
 
+// WaitStable polls the slice until it leaves its transitional states
+// (Nascent, Configuring, Modifying, ...) or ctx is done.
+func (s *slicesService) WaitStable(ctx context.Context, id string) (orchestrator.Slice, error) {
+	ctx = logCtx(ctx)
+	for {
+		sl, err := s.orc.GetSlice(ctx, id)
+		if err != nil {
+			return sl, err
+		}
+		tflog.SubsystemDebug(ctx, LogSubsystem, "Waiting for slice", map[string]interface{}{"slice_id": id, "state": sl.State})
+		if IsStable(sl.State) {
+			return sl, nil
+		}
+		select {
+		case <-ctx.Done():
+			return sl, ctx.Err()
+		case <-time.After(PollInterval):
+		}
+	}
+}
+
+// Delete deletes the slice and waits until it is Dead or gone, so its name
+// can be reused once Delete returns. ctx bounds the wait.
 func (s *slicesService) Delete(ctx context.Context, id string) error {
+	ctx, unlock, err := s.locks.LockContext(ctx, id)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	ctx = logCtx(ctx)
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Deleting slice", map[string]interface{}{"slice_id": id})
 	if err := s.orc.DeleteSlice(ctx, id); err != nil {
 		// Ignore “already gone” so Terraform destroy is idempotent.
-		if _, ok := err.(orchestrator.NotFoundError); ok {
+		if IsNotFound(err) {
 			return nil
 		}
 		return err
 	}
-	return nil
+
+	for {
+		sl, err := s.orc.GetSlice(ctx, id)
+		switch {
+		case IsNotFound(err):
+			return nil
+		case err != nil:
+			return err
+		case sl.State == "Dead":
+			return nil
+		}
+		tflog.SubsystemDebug(ctx, LogSubsystem, "Waiting for slice to close", map[string]interface{}{"slice_id": id, "state": sl.State})
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("slice %s was still %s when the delete timed out; it may finish closing later: %w", id, sl.State, ctx.Err())
+		case <-time.After(PollInterval):
+		}
+	}
+}
+
+// Modify submits graphXML as the slice's new topology, accepts it and waits
+// for the slice to settle.
+func (s *slicesService) Modify(ctx context.Context, id, xml string) (orchestrator.Slice, error) {
+	ctx, unlock, err := s.locks.LockContext(ctx, id)
+	if err != nil {
+		return orchestrator.Slice{}, err
+	}
+	defer unlock()
+	ctx = logCtx(ctx)
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Modifying slice", map[string]interface{}{"slice_id": id})
+	tflog.SubsystemTrace(ctx, LogSubsystem, "Slice modify graph", map[string]interface{}{"graphml": xml})
+
+	if err := s.orc.ModifySlice(ctx, id, xml); err != nil {
+		return orchestrator.Slice{}, err
+	}
+	if err := s.orc.AcceptModify(ctx, id); err != nil {
+		return orchestrator.Slice{}, err
+	}
+	return s.WaitStable(ctx, id)
+}
+
+// Edit reads the slice's current topology, lets fn change it and submits the
+// result through Modify. The whole cycle holds the slice's lock, so
+// concurrent edits of one slice cannot overwrite each other. If fn returns
+// ErrNoChange the slice as read is returned without modifying it.
+func (s *slicesService) Edit(ctx context.Context, id string, fn func(*topology.Model) error) (orchestrator.Slice, error) {
+	ctx, unlock, err := s.locks.LockContext(ctx, id)
+	if err != nil {
+		return orchestrator.Slice{}, err
+	}
+	defer unlock()
+
+	sl, err := s.orc.GetSlice(ctx, id)
+	if err != nil {
+		return sl, err
+	}
+	m, err := topology.ParseModelFormat(sl.ModelFormat, sl.Model)
+	if err != nil {
+		return sl, fmt.Errorf("slice %s: decode model: %w", id, err)
+	}
+	if err := fn(m); err != nil {
+		if errors.Is(err, ErrNoChange) {
+			return sl, nil
+		}
+		return sl, err
+	}
+	xml, err := topology.Marshal(topology.Encode(m))
+	if err != nil {
+		return sl, err
+	}
+	return s.Modify(ctx, id, xml)
+}
+
+// Renew extends the slice's lease and returns the lease end as sent to the
+// orchestrator.
+func (s *slicesService) Renew(ctx context.Context, id, leaseRFC3339 string) (string, error) {
+	lease, err := utils.NormalizeLease(leaseRFC3339)
+	if err != nil {
+		return "", err
+	}
+	ctx, unlock, err := s.locks.LockContext(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+	ctx = logCtx(ctx)
+	tflog.SubsystemInfo(ctx, LogSubsystem, "Renewing slice lease", map[string]interface{}{"slice_id": id, "lease_end_time": lease})
+	if err := s.orc.RenewSlice(ctx, id, lease); err != nil {
+		return "", err
+	}
+	return lease, nil
+}
+
+func (s *slicesService) List(ctx context.Context, name string, states []string) ([]orchestrator.Slice, error) {
+	return s.orc.ListSlices(ctx, name, states)
+}
+
+func (s *slicesService) Slivers(ctx context.Context, id string) ([]orchestrator.Sliver, error) {
+	return s.orc.ListSlivers(ctx, id)
+}
+
+// IsStable reports whether a slice state is terminal for provisioning.
+func IsStable(state string) bool {
+	switch state {
+	case "StableOK", "StableError", "ModifyOK", "ModifyError", "Closing", "Dead":
+		return true
+	}
+	return false
+}
+
+// ModifySucceeded reports whether a slice that settled in state after a
+// modify applied it cleanly.
+func ModifySucceeded(state string) bool {
+	return state == "StableOK" || state == "ModifyOK"
+}
+
+// IsGone reports whether a slice in state is closed or being closed.
+func IsGone(state string) bool {
+	return state == "Closing" || state == "Dead"
+}
+
+// IsNotFound reports whether err means the slice does not exist.
+func IsNotFound(err error) bool {
+	var nf orchestrator.NotFoundError
+	return errors.As(err, &nf)
 }
@@ -0,0 +1,296 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/clients/orchestrator"
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/topology"
+)
+
+// fakeOrchestrator records the calls it gets. GetSlice returns the model
+// last stored for the slice in models, which ModifySlice replaces.
+// ModifySlice for a slice ID in block waits until that channel is closed.
+type fakeOrchestrator struct {
+	mu      sync.Mutex
+	calls   []string
+	models  map[string]string
+	deleted map[string]bool
+	block   map[string]chan struct{}
+	entered chan string
+}
+
+func newFakeOrchestrator() *fakeOrchestrator {
+	return &fakeOrchestrator{
+		models:  map[string]string{},
+		deleted: map[string]bool{},
+		block:   map[string]chan struct{}{},
+		entered: make(chan string, 10),
+	}
+}
+
+func (f *fakeOrchestrator) record(call string) {
+	f.mu.Lock()
+	f.calls = append(f.calls, call)
+	f.mu.Unlock()
+}
+
+func (f *fakeOrchestrator) log() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+func (f *fakeOrchestrator) CreateSlice(ctx context.Context, name, leaseEnd, model string, sshKeys []string) (string, string, int, error) {
+	return "", "", 0, errors.New("not implemented")
+}
+
+func (f *fakeOrchestrator) GetSlice(ctx context.Context, id string) (orchestrator.Slice, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.deleted[id] {
+		return orchestrator.Slice{}, orchestrator.NotFoundError{}
+	}
+	return orchestrator.Slice{ID: id, State: "StableOK", Model: f.models[id]}, nil
+}
+
+func (f *fakeOrchestrator) DeleteSlice(ctx context.Context, id string) error {
+	f.record("delete " + id)
+	f.mu.Lock()
+	f.deleted[id] = true
+	f.mu.Unlock()
+	return nil
+}
+
+func (f *fakeOrchestrator) ModifySlice(ctx context.Context, id, model string) error {
+	f.record("modify " + id)
+	f.mu.Lock()
+	f.models[id] = model
+	block := f.block[id]
+	f.mu.Unlock()
+	f.entered <- id
+	if block != nil {
+		<-block
+	}
+	return nil
+}
+
+func (f *fakeOrchestrator) AcceptModify(ctx context.Context, id string) error {
+	f.record("accept " + id)
+	return nil
+}
+
+func (f *fakeOrchestrator) RenewSlice(ctx context.Context, id, leaseEnd string) error {
+	f.record("renew " + id)
+	return nil
+}
+
+func (f *fakeOrchestrator) ListSlices(ctx context.Context, name string, states []string) ([]orchestrator.Slice, error) {
+	return nil, nil
+}
+
+func (f *fakeOrchestrator) ListSlivers(ctx context.Context, id string) ([]orchestrator.Sliver, error) {
+	return nil, nil
+}
+
+func (f *fakeOrchestrator) ListResources(ctx context.Context, level *int32, includes, excludes []string) ([]string, error) {
+	return nil, nil
+}
+
+func TestSlicesServiceSerializesPerSlice(t *testing.T) {
+	orc := newFakeOrchestrator()
+	release := make(chan struct{})
+	orc.block["a"] = release
+	s := NewSlicesService(orc, nil)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 3)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, err := s.Modify(ctx, "a", "<graphml/>")
+		errs <- err
+	}()
+	if id := <-orc.entered; id != "a" {
+		t.Fatalf("first modify entered for %q", id)
+	}
+
+	// With a's modify in flight, a delete of a must wait while b proceeds.
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		errs <- s.Delete(ctx, "a")
+	}()
+	go func() {
+		defer wg.Done()
+		_, err := s.Modify(ctx, "b", "<graphml/>")
+		errs <- err
+	}()
+	if id := <-orc.entered; id != "b" {
+		t.Fatalf("second modify entered for %q", id)
+	}
+	time.Sleep(50 * time.Millisecond)
+	for _, c := range orc.log() {
+		if c == "delete a" {
+			t.Fatal("delete of a ran while its modify was in flight")
+		}
+	}
+
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var a []string
+	for _, c := range orc.log() {
+		if c[len(c)-1] == 'a' {
+			a = append(a, c)
+		}
+	}
+	if want := []string{"modify a", "accept a", "delete a"}; !reflect.DeepEqual(a, want) {
+		t.Fatalf("calls for a = %q, want %q", a, want)
+	}
+}
+
+func TestSlicesServiceLockWaitHonoursContext(t *testing.T) {
+	orc := newFakeOrchestrator()
+	release := make(chan struct{})
+	defer close(release)
+	orc.block["a"] = release
+	s := NewSlicesService(orc, nil)
+
+	go s.Modify(context.Background(), "a", "<graphml/>")
+	<-orc.entered
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := s.Delete(ctx, "a"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Delete error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if _, err := s.Renew(ctx, "a", "2030-01-01T00:00:00Z"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Renew error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	for _, c := range orc.log() {
+		if c == "delete a" || c == "renew a" {
+			t.Fatalf("%s reached the orchestrator without the lock", c)
+		}
+	}
+}
+
+func nodesModel(t *testing.T, names ...string) string {
+	t.Helper()
+	var cfg topology.TopologyConfig
+	for _, n := range names {
+		cfg.Nodes = append(cfg.Nodes, topology.NodeConfig{Name: n, Site: "RENC", Type: "VM"})
+	}
+	xml, err := topology.Marshal(topology.CreateCustomTopology(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return xml
+}
+
+func nodeNames(t *testing.T, model string) []string {
+	t.Helper()
+	m, err := topology.ParseModel(model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, n := range m.Nodes {
+		if n.Props["Class"] == "NetworkNode" {
+			names = append(names, n.Props["Name"])
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestSlicesServiceEdit(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		edit     func(*topology.Model) error
+		wantErr  string
+		modified bool
+		want     []string
+	}{
+		{
+			name: "merge",
+			edit: func(m *topology.Model) error {
+				frag, err := topology.ParseModel(nodesModel(t, "n3"))
+				if err != nil {
+					return err
+				}
+				m.Merge(frag)
+				return nil
+			},
+			modified: true,
+			want:     []string{"n1", "n2", "n3"},
+		},
+		{
+			name: "remove",
+			edit: func(m *topology.Model) error {
+				v, ok := m.FindByName("NetworkNode", "n1")
+				if !ok {
+					return errors.New("n1 not in model")
+				}
+				m.Remove(v.ID)
+				return nil
+			},
+			modified: true,
+			want:     []string{"n2"},
+		},
+		{
+			name:     "no change",
+			edit:     func(*topology.Model) error { return ErrNoChange },
+			modified: false,
+			want:     []string{"n1", "n2"},
+		},
+		{
+			name:     "callback error",
+			edit:     func(*topology.Model) error { return errors.New("name clash") },
+			wantErr:  "name clash",
+			modified: false,
+			want:     []string{"n1", "n2"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			orc := newFakeOrchestrator()
+			orc.models["s"] = nodesModel(t, "n1", "n2")
+			s := NewSlicesService(orc, nil)
+
+			sl, err := s.Edit(context.Background(), "s", tc.edit)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("Edit error = %v, want %q", err, tc.wantErr)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			calls := orc.log()
+			if modified := len(calls) > 0; modified != tc.modified {
+				t.Fatalf("orchestrator calls = %q, want modified = %v", calls, tc.modified)
+			}
+			if tc.modified && !reflect.DeepEqual(calls, []string{"modify s", "accept s"}) {
+				t.Errorf("orchestrator calls = %q, want modify and accept", calls)
+			}
+			if got := nodeNames(t, orc.models["s"]); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("slice nodes = %q, want %q", got, tc.want)
+			}
+			if tc.wantErr == "" && sl.Model != orc.models["s"] {
+				t.Error("Edit did not return the slice as it now stands")
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/clients/coreapi"
+)
+
+type SSHKeysService interface {
+	Create(ctx context.Context, req coreapi.CreateSSHKeyRequest) (coreapi.SSHKey, error)
+	Get(ctx context.Context, uuid string) (coreapi.SSHKey, error)
+	List(ctx context.Context) ([]coreapi.SSHKey, error)
+	Delete(ctx context.Context, uuid string) error
+}
+
+type sshKeysService struct{ core coreapi.Client }
+
+func NewSSHKeysService(core coreapi.Client) SSHKeysService { return &sshKeysService{core: core} }
+
+func (s *sshKeysService) Create(ctx context.Context, req coreapi.CreateSSHKeyRequest) (coreapi.SSHKey, error) {
+	return s.core.CreateSSHKey(ctx, req)
+}
+
+func (s *sshKeysService) Get(ctx context.Context, uuid string) (coreapi.SSHKey, error) {
+	return s.core.GetSSHKey(ctx, uuid)
+}
+
+func (s *sshKeysService) List(ctx context.Context) ([]coreapi.SSHKey, error) {
+	return s.core.ListSSHKeys(ctx)
+}
+
+func (s *sshKeysService) Delete(ctx context.Context, uuid string) error {
+	return s.core.DeleteSSHKey(ctx, uuid)
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"context"
+
+	"github.com/csc478-wcu/terraform-provider-fabric/internal/clients/credmgr"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const defaultTokenScope = "all"
+
+type TokensService interface {
+	Refresh(ctx context.Context, refreshToken, projectID, scope string) (credmgr.Token, error)
+}
+
+type tokensService struct{ cm credmgr.Client }
+
+func NewTokensService(cm credmgr.Client) TokensService { return &tokensService{cm: cm} }
+
+func (s *tokensService) Refresh(ctx context.Context, refreshToken, projectID, scope string) (credmgr.Token, error) {
+	if scope == "" {
+		scope = defaultTokenScope
+	}
+	ctx = logCtx(ctx)
+	if refreshToken != "" {
+		ctx = tflog.SubsystemMaskLogStrings(ctx, LogSubsystem, refreshToken)
+	}
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Refreshing id_token", map[string]interface{}{"project_id": projectID, "scope": scope})
+	return s.cm.Refresh(ctx, refreshToken, projectID, scope)
+}
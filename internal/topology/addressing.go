@@ -0,0 +1,52 @@
+package topology
+
+// FindByName returns the first vertex of the given class with a matching Name
+// property. Orchestrator-returned models re-key vertices, so names are the
+// only stable handle back to what we submitted.
+func (m *Model) FindByName(class, name string) (ModelNode, bool) {
+	for _, n := range m.Nodes {
+		if n.Props["Class"] == class && n.Props["Name"] == name {
+			return n, true
+		}
+	}
+	return ModelNode{}, false
+}
+
+// Gateway returns the subnet and gateway address the orchestrator assigned
+// to a FABNet network service, preferring IPv4.
+func (n ModelNode) Gateway() (subnet, gateway string) {
+	var gw struct {
+		IPv4       string `json:"ipv4"`
+		IPv4Subnet string `json:"ipv4_subnet"`
+		IPv6       string `json:"ipv6"`
+		IPv6Subnet string `json:"ipv6_subnet"`
+	}
+	if err := n.JSONProp("Gateway", &gw); err != nil {
+		return "", ""
+	}
+	if gw.IPv4 != "" || gw.IPv4Subnet != "" {
+		return gw.IPv4Subnet, gw.IPv4
+	}
+	return gw.IPv6Subnet, gw.IPv6
+}
+
+// IPAddr returns the address recorded in an interface's labels.
+func (n ModelNode) IPAddr() string {
+	var labels struct {
+		IPv4 string `json:"ipv4"`
+		IPv6 string `json:"ipv6"`
+	}
+	if err := n.JSONProp("Labels", &labels); err != nil {
+		return ""
+	}
+	if labels.IPv4 != "" {
+		return labels.IPv4
+	}
+	return labels.IPv6
+}
+
+// ManagementIP returns the management address of a node, set once its VM
+// is active.
+func (n ModelNode) ManagementIP() string {
+	return n.Props["MgmtIp"]
+}
@@ -0,0 +1,67 @@
+package topology
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// AdvertisedFacilityPort is a facility port interface as found in a FABRIC
+// resource advertisement.
+type AdvertisedFacilityPort struct {
+	Name       string
+	Site       string
+	Interface  string
+	LocalName  string
+	VLANRanges []string
+}
+
+// FacilityPorts extracts Facility nodes and their FacilityPort interfaces from
+// an advertisement model.
+func (m *Model) FacilityPorts() []AdvertisedFacilityPort {
+	var out []AdvertisedFacilityPort
+	for _, n := range m.Nodes {
+		if n.Props["Class"] != "NetworkNode" || n.Props["Type"] != "Facility" {
+			continue
+		}
+		for _, cp := range m.Children(n.ID) {
+			if cp.Props["Class"] != "ConnectionPoint" || cp.Props["Type"] != "FacilityPort" {
+				continue
+			}
+			var labels struct {
+				LocalName string          `json:"local_name"`
+				VLANRange json.RawMessage `json:"vlan_range"`
+			}
+			_ = cp.JSONProp("Labels", &labels) // tolerate malformed labels
+			out = append(out, AdvertisedFacilityPort{
+				Name:       n.Props["Name"],
+				Site:       n.Props["Site"],
+				Interface:  cp.Props["Name"],
+				LocalName:  labels.LocalName,
+				VLANRanges: stringOrList(labels.VLANRange),
+			})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Name != out[j].Name {
+			return out[i].Name < out[j].Name
+		}
+		return out[i].Interface < out[j].Interface
+	})
+	return out
+}
+
+// stringOrList accepts either "100-200" or ["100-200","300"].
+func stringOrList(raw json.RawMessage) []string {
+	if len(raw) == 0 {
+		return nil
+	}
+	var list []string
+	if json.Unmarshal(raw, &list) == nil {
+		return list
+	}
+	var single string
+	if json.Unmarshal(raw, &single) == nil && single != "" {
+		return []string{single}
+	}
+	return nil
+}
@@ -1,7 +1,13 @@
 package topology
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+)
 
+// NodeConfig is a NetworkNode. Type is "VM" or "Switch" (P4); switches
+// ignore ImageRef, InstanceType and the core/ram/disk sizing.
 type NodeConfig struct {
 	Name         string
 	Site         string
@@ -19,12 +25,79 @@ type LinkConfig struct {
 	Target string
 }
 
+// FacilityPortConfig describes a FABRIC facility port (campus network,
+// cloud interconnect, ...). It is emitted as a Facility node owning a single
+// FacilityPort interface that links can attach to.
+type FacilityPortConfig struct {
+	Name      string
+	Site      string
+	VLAN      string
+	Bandwidth int64
+	Labels    map[string]string
+}
+
+// InterfaceConfig is one attachment of a network service. Interfaces on VMs
+// get their own NIC component (see NICModel), interfaces on switches are
+// switch ports, and interfaces on facility ports reuse the port's interface
+// vertex.
+type InterfaceConfig struct {
+	Name      string
+	Node      string
+	VLAN      string
+	Bandwidth int64
+	MAC       string
+	IPAddr    string
+	NICModel  string
+}
+
+// nicComponent maps a FABRIC NIC model name to the component type, hardware
+// model and port type used in the request graph.
+func nicComponent(model string) (compType, hwModel, portType string) {
+	switch model {
+	case "NIC_ConnectX_5":
+		return "SmartNIC", "ConnectX-5", "DedicatedPort"
+	case "NIC_ConnectX_6":
+		return "SmartNIC", "ConnectX-6", "DedicatedPort"
+	default: // NIC_Basic
+		return "SharedNIC", "ConnectX-6", "SharedPort"
+	}
+}
+
+// NICModel is the FABRIC NIC model name of a NIC component, the inverse of
+// nicComponent.
+func NICModel(comp ModelNode) string {
+	if comp.Props["Type"] != "SmartNIC" {
+		return "NIC_Basic"
+	}
+	if comp.Props["Model"] == "ConnectX-5" {
+		return "NIC_ConnectX_5"
+	}
+	return "NIC_ConnectX_6"
+}
+
+type NetworkServiceConfig struct {
+	Name       string
+	Type       string
+	Site       string
+	Interfaces []InterfaceConfig
+
+	// PortMirror only: the advertised switch port to mirror and the
+	// direction ("both", "rx", "tx") into the single target interface.
+	MirrorPort      string
+	MirrorDirection string
+}
+
 type TopologyConfig struct {
-	GraphID string
-	Nodes   []NodeConfig
-	Links   []LinkConfig
+	GraphID         string
+	Nodes           []NodeConfig
+	Links           []LinkConfig
+	FacilityPorts   []FacilityPortConfig
+	NetworkServices []NetworkServiceConfig
 }
 
+// FacilityPortInterface returns the vertex id of a facility port's interface.
+func FacilityPortInterface(name string) string { return name + "-int" }
+
 func CreateCustomTopology(config TopologyConfig) GraphML {
 	keys := []Key{
 		{ID: "Site", For: "node", AttrName: "Site", AttrType: "string"},
@@ -32,6 +105,10 @@ func CreateCustomTopology(config TopologyConfig) GraphML {
 		{ID: "Type", For: "node", AttrName: "Type", AttrType: "string"},
 		{ID: "CapacityHints", For: "node", AttrName: "CapacityHints", AttrType: "string"},
 		{ID: "Capacities", For: "node", AttrName: "Capacities", AttrType: "string"},
+		{ID: "Labels", For: "node", AttrName: "Labels", AttrType: "string"},
+		{ID: "Model", For: "node", AttrName: "Model", AttrType: "string"},
+		{ID: "MirrorPort", For: "node", AttrName: "MirrorPort", AttrType: "string"},
+		{ID: "MirrorDirection", For: "node", AttrName: "MirrorDirection", AttrType: "string"},
 		{ID: "NodeID", For: "node", AttrName: "NodeID", AttrType: "string"},
 		{ID: "GraphID", For: "node", AttrName: "GraphID", AttrType: "string"},
 		{ID: "Name", For: "node", AttrName: "Name", AttrType: "string"},
@@ -42,7 +119,25 @@ func CreateCustomTopology(config TopologyConfig) GraphML {
 	}
 
 	var nodes []Node
+	switches := map[string]bool{}
 	for i, n := range config.Nodes {
+		if n.Type == "Switch" {
+			switches[n.Name] = true
+			nodes = append(nodes, Node{
+				ID: n.Name,
+				Data: []Data{
+					{Key: "Site", Value: n.Site},
+					{Key: "Type", Value: n.Type},
+					{Key: "Capacities", Value: `{"unit":1}`},
+					{Key: "NodeID", Value: n.Name},
+					{Key: "GraphID", Value: config.GraphID},
+					{Key: "Name", Value: n.Name},
+					{Key: "Class", Value: "NetworkNode"},
+					{Key: "id", Value: fmt.Sprintf("%d", i+1)},
+				},
+			})
+			continue
+		}
 		capacityHints := fmt.Sprintf(`{"instance_type":"%s"}`, n.InstanceType)
 		capacities := fmt.Sprintf(`{"core":%d,"ram":%d,"disk":%d}`, n.Cores, n.RAM, n.Disk)
 		nodes = append(nodes, Node{
@@ -63,10 +158,65 @@ func CreateCustomTopology(config TopologyConfig) GraphML {
 	}
 
 	var edges []Edge
+	facilityIfaces := make(map[string]string, len(config.FacilityPorts))
+	for _, fp := range config.FacilityPorts {
+		iface := FacilityPortInterface(fp.Name)
+		facilityIfaces[fp.Name] = iface
+
+		labels := make(map[string]string, len(fp.Labels)+1)
+		for k, v := range fp.Labels {
+			labels[k] = v
+		}
+		if fp.VLAN != "" {
+			labels["vlan"] = fp.VLAN
+		}
+		labelsJSON, _ := json.Marshal(labels) // map[string]string cannot fail
+
+		nodes = append(nodes,
+			Node{
+				ID: fp.Name,
+				Data: []Data{
+					{Key: "Site", Value: fp.Site},
+					{Key: "Type", Value: "Facility"},
+					{Key: "NodeID", Value: fp.Name},
+					{Key: "GraphID", Value: config.GraphID},
+					{Key: "Name", Value: fp.Name},
+					{Key: "Class", Value: "NetworkNode"},
+					{Key: "id", Value: fmt.Sprintf("%d", len(nodes)+1)},
+				},
+			},
+			Node{
+				ID: iface,
+				Data: []Data{
+					{Key: "Type", Value: "FacilityPort"},
+					{Key: "Labels", Value: string(labelsJSON)},
+					{Key: "Capacities", Value: fmt.Sprintf(`{"bw":%d}`, fp.Bandwidth)},
+					{Key: "NodeID", Value: iface},
+					{Key: "GraphID", Value: config.GraphID},
+					{Key: "Name", Value: iface},
+					{Key: "Class", Value: "ConnectionPoint"},
+					{Key: "id", Value: fmt.Sprintf("%d", len(nodes)+2)},
+				},
+			},
+		)
+		edges = append(edges, Edge{
+			Source: fp.Name,
+			Target: iface,
+			Data:   []Data{{Key: "Class", Value: "has"}},
+		})
+	}
+
+	// Links that name a facility port attach to its interface vertex.
+	endpoint := func(name string) string {
+		if iface, ok := facilityIfaces[name]; ok {
+			return iface
+		}
+		return name
+	}
 	for _, e := range config.Links {
 		edges = append(edges, Edge{
-			Source: e.Source,
-			Target: e.Target,
+			Source: endpoint(e.Source),
+			Target: endpoint(e.Target),
 			Data: []Data{
 				{Key: "Class", Value: "Link"},
 				{Key: "Name", Value: e.Name},
@@ -74,9 +224,128 @@ func CreateCustomTopology(config TopologyConfig) GraphML {
 		})
 	}
 
+	for _, ns := range config.NetworkServices {
+		data := []Data{
+			{Key: "Type", Value: ns.Type},
+			{Key: "NodeID", Value: ns.Name},
+			{Key: "GraphID", Value: config.GraphID},
+			{Key: "Name", Value: ns.Name},
+			{Key: "Class", Value: "NetworkService"},
+			{Key: "id", Value: fmt.Sprintf("%d", len(nodes)+1)},
+		}
+		if ns.Site != "" {
+			data = append([]Data{{Key: "Site", Value: ns.Site}}, data...)
+		}
+		if ns.MirrorPort != "" {
+			dir := ns.MirrorDirection
+			if dir == "" {
+				dir = "both"
+			}
+			data = append(data,
+				Data{Key: "MirrorPort", Value: ns.MirrorPort},
+				Data{Key: "MirrorDirection", Value: dir},
+			)
+		}
+		nodes = append(nodes, Node{ID: ns.Name, Data: data})
+
+		for _, ifc := range ns.Interfaces {
+			if fpIface, ok := facilityIfaces[ifc.Node]; ok {
+				edges = append(edges, Edge{
+					Source: ns.Name,
+					Target: fpIface,
+					Data:   []Data{{Key: "Class", Value: "connects"}},
+				})
+				continue
+			}
+
+			if switches[ifc.Node] {
+				// P4 switch ports hang directly off the switch, no NIC.
+				nodes = append(nodes, Node{
+					ID: ifc.Name,
+					Data: []Data{
+						{Key: "Type", Value: "DedicatedPort"},
+						{Key: "Labels", Value: interfaceLabels(ifc)},
+						{Key: "Capacities", Value: interfaceCapacities(ifc)},
+						{Key: "NodeID", Value: ifc.Name},
+						{Key: "GraphID", Value: config.GraphID},
+						{Key: "Name", Value: ifc.Name},
+						{Key: "Class", Value: "ConnectionPoint"},
+						{Key: "id", Value: fmt.Sprintf("%d", len(nodes)+1)},
+					},
+				})
+				edges = append(edges,
+					Edge{Source: ifc.Node, Target: ifc.Name, Data: []Data{{Key: "Class", Value: "has"}}},
+					Edge{Source: ns.Name, Target: ifc.Name, Data: []Data{{Key: "Class", Value: "connects"}}},
+				)
+				continue
+			}
+
+			nic := ifc.Name + "-nic"
+			compType, hwModel, portType := nicComponent(ifc.NICModel)
+			nodes = append(nodes,
+				Node{
+					ID: nic,
+					Data: []Data{
+						{Key: "Type", Value: compType},
+						{Key: "Model", Value: hwModel},
+						{Key: "NodeID", Value: nic},
+						{Key: "GraphID", Value: config.GraphID},
+						{Key: "Name", Value: nic},
+						{Key: "Class", Value: "Component"},
+						{Key: "id", Value: fmt.Sprintf("%d", len(nodes)+1)},
+					},
+				},
+				Node{
+					ID: ifc.Name,
+					Data: []Data{
+						{Key: "Type", Value: portType},
+						{Key: "Labels", Value: interfaceLabels(ifc)},
+						{Key: "Capacities", Value: interfaceCapacities(ifc)},
+						{Key: "NodeID", Value: ifc.Name},
+						{Key: "GraphID", Value: config.GraphID},
+						{Key: "Name", Value: ifc.Name},
+						{Key: "Class", Value: "ConnectionPoint"},
+						{Key: "id", Value: fmt.Sprintf("%d", len(nodes)+2)},
+					},
+				},
+			)
+			edges = append(edges,
+				Edge{Source: ifc.Node, Target: nic, Data: []Data{{Key: "Class", Value: "has"}}},
+				Edge{Source: nic, Target: ifc.Name, Data: []Data{{Key: "Class", Value: "has"}}},
+				Edge{Source: ns.Name, Target: ifc.Name, Data: []Data{{Key: "Class", Value: "connects"}}},
+			)
+		}
+	}
+
 	return GraphML{
 		Xmlns: "http://graphml.graphdrawing.org/xmlns",
 		Keys:  keys,
 		Graph: Graph{Edgedefault: "directed", Nodes: nodes, Edges: edges},
 	}
 }
+
+func interfaceLabels(ifc InterfaceConfig) string {
+	labels := map[string]string{}
+	if ifc.VLAN != "" {
+		labels["vlan"] = ifc.VLAN
+	}
+	if ifc.MAC != "" {
+		labels["mac"] = ifc.MAC
+	}
+	if ip := net.ParseIP(ifc.IPAddr); ip != nil {
+		if ip.To4() != nil {
+			labels["ipv4"] = ifc.IPAddr
+		} else {
+			labels["ipv6"] = ifc.IPAddr
+		}
+	}
+	b, _ := json.Marshal(labels) // map[string]string cannot fail
+	return string(b)
+}
+
+func interfaceCapacities(ifc InterfaceConfig) string {
+	if ifc.Bandwidth == 0 {
+		return "{}"
+	}
+	return fmt.Sprintf(`{"bw":%d}`, ifc.Bandwidth)
+}
@@ -0,0 +1,66 @@
+package topology
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// CYTOSCAPE is networkx's cytoscape_data format. Every element carries its
+// attributes under "data"; nodes also repeat their id as "name" and "value".
+// Properties named like those members are escaped as in JSON_NODELINK.
+
+type cytoscapeElement struct {
+	Data map[string]any `json:"data"`
+}
+
+type cytoscapeDoc struct {
+	Data       map[string]any `json:"data"`
+	Directed   bool           `json:"directed"`
+	Multigraph bool           `json:"multigraph"`
+	Elements   struct {
+		Nodes []cytoscapeElement `json:"nodes"`
+		Edges []cytoscapeElement `json:"edges"`
+	} `json:"elements"`
+}
+
+func MarshalCytoscape(m *Model) (string, error) {
+	doc := cytoscapeDoc{Data: map[string]any{}, Directed: true}
+	doc.Elements.Nodes = make([]cytoscapeElement, 0, len(m.Nodes))
+	doc.Elements.Edges = make([]cytoscapeElement, 0, len(m.Edges))
+	for _, n := range m.Nodes {
+		data := joinProps(n.Props, "id", "name", "value")
+		data["id"], data["name"], data["value"] = n.ID, n.ID, n.ID
+		doc.Elements.Nodes = append(doc.Elements.Nodes, cytoscapeElement{Data: data})
+	}
+	for _, e := range m.Edges {
+		data := joinProps(e.Props, "source", "target", "key")
+		data["source"], data["target"] = e.Source, e.Target
+		doc.Elements.Edges = append(doc.Elements.Edges, cytoscapeElement{Data: data})
+	}
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func UnmarshalCytoscape(s string) (*Model, error) {
+	var doc cytoscapeDoc
+	dec := json.NewDecoder(bytes.NewReader([]byte(s)))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decode cytoscape graph: %w", err)
+	}
+
+	m := &Model{}
+	for _, el := range doc.Elements.Nodes {
+		id, props := splitProps(el.Data, "id", "name", "value")
+		m.Nodes = append(m.Nodes, ModelNode{ID: id["id"], Props: props})
+	}
+	for _, el := range doc.Elements.Edges {
+		ends, props := splitProps(el.Data, "source", "target", "key")
+		m.Edges = append(m.Edges, ModelEdge{Source: ends["source"], Target: ends["target"], Props: props})
+	}
+	return m, nil
+}
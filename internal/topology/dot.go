@@ -0,0 +1,96 @@
+package topology
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// RenderDOT draws a decoded model as a Graphviz digraph: nodes are boxes
+// (facilities are houses), components ellipses, network services diamonds
+// and interfaces points. "has" edges are solid, "connects" edges dashed and
+// direct links bold.
+func RenderDOT(m *Model) string {
+	var b strings.Builder
+	b.WriteString("digraph topology {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [fontname=\"Helvetica\", fontsize=10];\n")
+	b.WriteString("  edge [arrowhead=none];\n")
+
+	for _, n := range m.Nodes {
+		fmt.Fprintf(&b, "  %s [%s];\n", dotQuote(n.ID), dotNodeAttrs(n))
+	}
+	for _, e := range m.Edges {
+		style := "solid"
+		switch e.Props["Class"] {
+		case "connects":
+			style = "dashed"
+		case "Link":
+			style = "bold"
+		}
+		attrs := "style=" + style
+		if name := e.Props["Name"]; name != "" {
+			attrs += ", label=" + dotQuote(name)
+		}
+		fmt.Fprintf(&b, "  %s -> %s [%s];\n", dotQuote(e.Source), dotQuote(e.Target), attrs)
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func dotNodeAttrs(n ModelNode) string {
+	name := n.Props["Name"]
+	if name == "" {
+		name = n.ID
+	}
+	typ := n.Props["Type"]
+
+	switch n.Props["Class"] {
+	case "NetworkNode":
+		shape := "box"
+		if typ == "Facility" {
+			shape = "house"
+		}
+		label := name + "\n" + typ
+		if site := n.Props["Site"]; site != "" {
+			label += " @ " + site
+		}
+		return fmt.Sprintf("shape=%s, label=%s", shape, dotQuote(label))
+	case "Component":
+		label := name + "\n" + typ
+		if model := n.Props["Model"]; model != "" {
+			label += " " + model
+		}
+		return fmt.Sprintf("shape=ellipse, label=%s", dotQuote(label))
+	case "NetworkService":
+		return fmt.Sprintf("shape=diamond, label=%s", dotQuote(name+"\n"+typ))
+	case "ConnectionPoint":
+		return fmt.Sprintf("shape=point, xlabel=%s", dotQuote(name))
+	default:
+		return fmt.Sprintf("shape=box, style=dashed, label=%s", dotQuote(name))
+	}
+}
+
+// dotQuote writes s as a DOT quoted string. Newlines become DOT's centred
+// line break; backslashes are doubled so names cannot form escapes such as
+// \N or \l, and other control characters are replaced by spaces.
+func dotQuote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case unicode.IsControl(r):
+			b.WriteByte(' ')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
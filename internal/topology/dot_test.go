@@ -0,0 +1,103 @@
+package topology
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderDOT(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		m    *Model
+		want []string
+	}{
+		{
+			name: "empty",
+			m:    &Model{},
+			want: []string{"digraph topology {\n  rankdir=LR;\n", "}\n"},
+		},
+		{
+			name: "node",
+			m: &Model{Nodes: []ModelNode{
+				{ID: "n1", Props: map[string]string{"Class": "NetworkNode", "Name": "vm1", "Type": "VM", "Site": "RENC"}},
+			}},
+			want: []string{`  "n1" [shape=box, label="vm1\nVM @ RENC"];`},
+		},
+		{
+			name: "facility without site",
+			m: &Model{Nodes: []ModelNode{
+				{ID: "f1", Props: map[string]string{"Class": "NetworkNode", "Name": "fp", "Type": "Facility"}},
+			}},
+			want: []string{`  "f1" [shape=house, label="fp\nFacility"];`},
+		},
+		{
+			name: "component",
+			m: &Model{Nodes: []ModelNode{
+				{ID: "c1", Props: map[string]string{"Class": "Component", "Name": "nic1", "Type": "SmartNIC", "Model": "ConnectX-6"}},
+			}},
+			want: []string{`  "c1" [shape=ellipse, label="nic1\nSmartNIC ConnectX-6"];`},
+		},
+		{
+			name: "network service and interface",
+			m: &Model{
+				Nodes: []ModelNode{
+					{ID: "s1", Props: map[string]string{"Class": "NetworkService", "Name": "net", "Type": "L2Bridge"}},
+					{ID: "i1", Props: map[string]string{"Class": "ConnectionPoint", "Name": "vm1-nic1-p1"}},
+				},
+				Edges: []ModelEdge{{Source: "s1", Target: "i1", Props: map[string]string{"Class": "connects"}}},
+			},
+			want: []string{
+				`  "s1" [shape=diamond, label="net\nL2Bridge"];`,
+				`  "i1" [shape=point, xlabel="vm1-nic1-p1"];`,
+				`  "s1" -> "i1" [style=dashed];`,
+			},
+		},
+		{
+			name: "links",
+			m: &Model{Edges: []ModelEdge{
+				{Source: "a", Target: "b", Props: map[string]string{"Class": "Link", "Name": "l1"}},
+				{Source: "a", Target: "c", Props: map[string]string{"Class": "has"}},
+			}},
+			want: []string{
+				`  "a" -> "b" [style=bold, label="l1"];`,
+				`  "a" -> "c" [style=solid];`,
+			},
+		},
+		{
+			name: "unknown class falls back to id",
+			m:    &Model{Nodes: []ModelNode{{ID: "x1", Props: map[string]string{}}}},
+			want: []string{`  "x1" [shape=box, style=dashed, label="x1"];`},
+		},
+		{
+			name: "quoting",
+			m: &Model{
+				Nodes: []ModelNode{
+					{ID: `n"1`, Props: map[string]string{"Class": "NetworkNode", "Name": `say "hi"\N`, "Type": "VM"}},
+					{ID: "n2", Props: map[string]string{"Class": "ConnectionPoint", "Name": "tab\there"}},
+				},
+				Edges: []ModelEdge{{Source: `n"1`, Target: `back\slash`, Props: map[string]string{"Class": "Link", "Name": "two\nlines"}}},
+			},
+			want: []string{
+				`  "n\"1" [shape=box, label="say \"hi\"\\N\nVM"];`,
+				`  "n2" [shape=point, xlabel="tab here"];`,
+				`  "n\"1" -> "back\\slash" [style=bold, label="two\nlines"];`,
+			},
+		},
+		{
+			name: "unicode kept",
+			m: &Model{Nodes: []ModelNode{
+				{ID: "n1", Props: map[string]string{"Class": "NetworkService", "Name": "réseau", "Type": "FABNetv4"}},
+			}},
+			want: []string{`  "n1" [shape=diamond, label="réseau\nFABNetv4"];`},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := RenderDOT(tc.m)
+			for _, w := range tc.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("missing %s\nin\n%s", w, got)
+				}
+			}
+		})
+	}
+}
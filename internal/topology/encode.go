@@ -0,0 +1,52 @@
+package topology
+
+import "sort"
+
+// Encode is the inverse of Decode: it rebuilds a GraphML document from a
+// Model, declaring one key per property name (key id == attribute name, as
+// CreateCustomTopology does).
+func Encode(m *Model) GraphML {
+	nodeKeys := map[string]bool{}
+	edgeKeys := map[string]bool{}
+
+	nodes := make([]Node, 0, len(m.Nodes))
+	for _, n := range m.Nodes {
+		nodes = append(nodes, Node{ID: n.ID, Data: sortedData(n.Props, nodeKeys)})
+	}
+	edges := make([]Edge, 0, len(m.Edges))
+	for _, e := range m.Edges {
+		edges = append(edges, Edge{Source: e.Source, Target: e.Target, Data: sortedData(e.Props, edgeKeys)})
+	}
+
+	var keys []Key
+	for _, k := range sortedKeys(nodeKeys) {
+		keys = append(keys, Key{ID: k, For: "node", AttrName: k, AttrType: "string"})
+	}
+	for _, k := range sortedKeys(edgeKeys) {
+		keys = append(keys, Key{ID: k, For: "edge", AttrName: k, AttrType: "string"})
+	}
+
+	return GraphML{
+		Xmlns: "http://graphml.graphdrawing.org/xmlns",
+		Keys:  keys,
+		Graph: Graph{Edgedefault: "directed", Nodes: nodes, Edges: edges},
+	}
+}
+
+func sortedData(props map[string]string, seen map[string]bool) []Data {
+	out := make([]Data, 0, len(props))
+	for _, k := range sortedKeys(props) {
+		seen[k] = true
+		out = append(out, Data{Key: k, Value: props[k]})
+	}
+	return out
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,39 @@
+package topology
+
+import "fmt"
+
+// Graph formats understood by the orchestrator's graph_format parameter.
+const (
+	FormatGraphML      = "GRAPHML"
+	FormatJSONNodeLink = "JSON_NODELINK"
+	FormatCytoscape    = "CYTOSCAPE"
+)
+
+// ParseModelFormat decodes a slice or advertisement model returned in the
+// given graph format. An empty format means GraphML.
+func ParseModelFormat(format, s string) (*Model, error) {
+	switch format {
+	case "", FormatGraphML:
+		return ParseModel(s)
+	case FormatJSONNodeLink:
+		return UnmarshalNodeLink(s)
+	case FormatCytoscape:
+		return UnmarshalCytoscape(s)
+	default:
+		return nil, fmt.Errorf("cannot decode graph format %q", format)
+	}
+}
+
+// FormatModel serializes m in the given graph format.
+func FormatModel(format string, m *Model) (string, error) {
+	switch format {
+	case "", FormatGraphML:
+		return Marshal(Encode(m))
+	case FormatJSONNodeLink:
+		return MarshalNodeLink(m)
+	case FormatCytoscape:
+		return MarshalCytoscape(m)
+	default:
+		return "", fmt.Errorf("cannot encode graph format %q", format)
+	}
+}
@@ -0,0 +1,112 @@
+package topology
+
+import (
+	"reflect"
+	"testing"
+)
+
+const roundTripGraphML = `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="d0" for="node" attr.name="Class" attr.type="string"/>
+  <key id="d1" for="node" attr.name="Name" attr.type="string"/>
+  <key id="d2" for="node" attr.name="id" attr.type="string"/>
+  <key id="d3" for="node" attr.name="name" attr.type="string"/>
+  <key id="d4" for="node" attr.name="_value" attr.type="string"/>
+  <key id="d5" for="node" attr.name="Capacities" attr.type="string"/>
+  <key id="d6" for="edge" attr.name="Class" attr.type="string"/>
+  <key id="d7" for="edge" attr.name="source" attr.type="string"/>
+  <key id="d8" for="edge" attr.name="key" attr.type="string"/>
+  <graph edgedefault="directed">
+    <node id="n1">
+      <data key="d0">NetworkNode</data>
+      <data key="d1">vm1</data>
+      <data key="d2">prop-id</data>
+      <data key="d3">prop-name</data>
+      <data key="d4">prop-value</data>
+      <data key="d5">{"core": 2, "ram": 8}</data>
+    </node>
+    <node id="n2">
+      <data key="d0">Component</data>
+      <data key="d1">nic1</data>
+    </node>
+    <edge source="n1" target="n2">
+      <data key="d6">has</data>
+      <data key="d7">prop-source</data>
+      <data key="d8">prop-key</data>
+    </edge>
+  </graph>
+</graphml>`
+
+func TestFormatRoundTrip(t *testing.T) {
+	want, err := ParseModel(roundTripGraphML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantXML, err := FormatModel(FormatGraphML, want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range []string{FormatJSONNodeLink, FormatCytoscape} {
+		t.Run(format, func(t *testing.T) {
+			s, err := FormatModel(format, want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ParseModelFormat(format, s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("model after %s round trip:\n got %+v\nwant %+v", format, got, want)
+			}
+
+			xml, err := FormatModel(FormatGraphML, got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if xml != wantXML {
+				t.Fatalf("GraphML after %s round trip:\n%s\nwant\n%s", format, xml, wantXML)
+			}
+		})
+	}
+}
+
+func TestUnmarshalNodeLinkNetworkx(t *testing.T) {
+	// As written by networkx.node_link_data with edges="edges" (>= 3.4).
+	const doc = `{"directed": true, "multigraph": false, "graph": {},
+	  "nodes": [{"Class": "NetworkNode", "Cores": 4, "Up": true, "Labels": {"a": 1}, "id": "n1"}],
+	  "edges": [{"Class": "has", "source": "n1", "target": "n2", "key": 0}]}`
+	m, err := UnmarshalNodeLink(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Model{
+		Nodes: []ModelNode{{ID: "n1", Props: map[string]string{
+			"Class": "NetworkNode", "Cores": "4", "Up": "true", "Labels": `{"a":1}`,
+		}}},
+		Edges: []ModelEdge{{Source: "n1", Target: "n2", Props: map[string]string{"Class": "has"}}},
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Fatalf("got %+v\nwant %+v", m, want)
+	}
+}
+
+func TestEscapeProp(t *testing.T) {
+	reserved := []string{"id", "source"}
+	for _, tc := range []struct{ in, out string }{
+		{"id", "_id"},
+		{"_id", "__id"},
+		{"source", "_source"},
+		{"Name", "Name"},
+		{"_Name", "_Name"},
+		{"idx", "idx"},
+	} {
+		if got := escapeProp(tc.in, reserved); got != tc.out {
+			t.Errorf("escapeProp(%q) = %q, want %q", tc.in, got, tc.out)
+		}
+		if got := unescapeProp(tc.out, reserved); got != tc.in {
+			t.Errorf("unescapeProp(%q) = %q, want %q", tc.out, got, tc.in)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package topology
+
+import "encoding/json"
+
+// Model is a GraphML document with data keys resolved to their attribute
+// names, so callers can read vertices and edges without caring whether the
+// orchestrator used "Site" or "d3" as the key id.
+type Model struct {
+	Nodes []ModelNode
+	Edges []ModelEdge
+}
+
+type ModelNode struct {
+	ID    string
+	Props map[string]string
+}
+
+type ModelEdge struct {
+	Source string
+	Target string
+	Props  map[string]string
+}
+
+func Decode(g GraphML) *Model {
+	names := make(map[string]string, len(g.Keys))
+	for _, k := range g.Keys {
+		names[k.ID] = k.AttrName
+	}
+	props := func(data []Data) map[string]string {
+		out := make(map[string]string, len(data))
+		for _, d := range data {
+			name := names[d.Key]
+			if name == "" {
+				name = d.Key
+			}
+			out[name] = d.Value
+		}
+		return out
+	}
+
+	m := &Model{}
+	for _, n := range g.Graph.Nodes {
+		m.Nodes = append(m.Nodes, ModelNode{ID: n.ID, Props: props(n.Data)})
+	}
+	for _, e := range g.Graph.Edges {
+		m.Edges = append(m.Edges, ModelEdge{Source: e.Source, Target: e.Target, Props: props(e.Data)})
+	}
+	return m
+}
+
+// ParseModel unmarshals and decodes a GraphML string in one step.
+func ParseModel(s string) (*Model, error) {
+	g, err := Unmarshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return Decode(g), nil
+}
+
+func (m *Model) Node(id string) (ModelNode, bool) {
+	for _, n := range m.Nodes {
+		if n.ID == id {
+			return n, true
+		}
+	}
+	return ModelNode{}, false
+}
+
+// Children returns the vertices reachable from id over "has" edges, in
+// breadth-first order (e.g. a node's components and their interfaces).
+func (m *Model) Children(id string) []ModelNode {
+	var out []ModelNode
+	seen := map[string]bool{id: true}
+	queue := []string{id}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, e := range m.Edges {
+			if e.Source != cur || e.Props["Class"] != "has" || seen[e.Target] {
+				continue
+			}
+			seen[e.Target] = true
+			if n, ok := m.Node(e.Target); ok {
+				out = append(out, n)
+			}
+			queue = append(queue, e.Target)
+		}
+	}
+	return out
+}
+
+// JSONProp decodes a JSON-valued property (Labels, Capacities, ...) into v.
+// Missing properties leave v untouched.
+func (n ModelNode) JSONProp(key string, v any) error {
+	raw := n.Props[key]
+	if raw == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(raw), v)
+}
@@ -0,0 +1,124 @@
+package topology
+
+import "github.com/google/uuid"
+
+// GraphID returns the graph id recorded on the model's vertices.
+func (m *Model) GraphID() string {
+	for _, n := range m.Nodes {
+		if id := n.Props["GraphID"]; id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// Merge adds the vertices and edges of frag (typically built with
+// CreateCustomTopology) to m, for a slice modify request.
+//
+// Fragment vertices that already exist in m, matched by Class and Name, are
+// not duplicated: edges to them are redirected to the existing vertex, and
+// edges between two existing vertices are dropped. New vertices get fresh
+// ids so they cannot clash with the ids the orchestrator assigned.
+func (m *Model) Merge(frag *Model) {
+	graphID := m.GraphID()
+
+	ids := make(map[string]string, len(frag.Nodes))
+	existing := map[string]bool{}
+	for _, n := range frag.Nodes {
+		if old, ok := m.FindByName(n.Props["Class"], n.Props["Name"]); ok {
+			ids[n.ID] = old.ID
+			existing[n.ID] = true
+			continue
+		}
+		id := uuid.NewString()
+		ids[n.ID] = id
+
+		props := make(map[string]string, len(n.Props))
+		for k, v := range n.Props {
+			props[k] = v
+		}
+		props["NodeID"] = id
+		if graphID != "" {
+			props["GraphID"] = graphID
+		}
+		m.Nodes = append(m.Nodes, ModelNode{ID: id, Props: props})
+	}
+
+	for _, e := range frag.Edges {
+		if existing[e.Source] && existing[e.Target] {
+			continue
+		}
+		src, tgt := e.Source, e.Target
+		if id, ok := ids[src]; ok {
+			src = id
+		}
+		if id, ok := ids[tgt]; ok {
+			tgt = id
+		}
+		m.Edges = append(m.Edges, ModelEdge{Source: src, Target: tgt, Props: e.Props})
+	}
+}
+
+// Remove deletes vertex id, everything it "has" (components, interfaces)
+// and every edge touching them.
+func (m *Model) Remove(id string) {
+	gone := map[string]bool{id: true}
+	for _, c := range m.Children(id) {
+		gone[c.ID] = true
+	}
+	m.drop(gone)
+}
+
+// RemoveService deletes a network service and the interfaces it created:
+// NIC components on VMs (with their ports) and switch ports. Facility port
+// interfaces belong to the facility port and are kept.
+func (m *Model) RemoveService(id string) {
+	gone := map[string]bool{id: true}
+	for _, e := range m.Edges {
+		if e.Source != id || e.Props["Class"] != "connects" {
+			continue
+		}
+		parent, ok := m.Parent(e.Target)
+		if !ok {
+			continue
+		}
+		switch {
+		case parent.Props["Class"] == "Component":
+			gone[parent.ID] = true
+			for _, c := range m.Children(parent.ID) {
+				gone[c.ID] = true
+			}
+		case parent.Props["Type"] == "Switch":
+			gone[e.Target] = true
+		}
+	}
+	m.drop(gone)
+}
+
+// Parent returns the vertex that "has" id.
+func (m *Model) Parent(id string) (ModelNode, bool) {
+	for _, e := range m.Edges {
+		if e.Target == id && e.Props["Class"] == "has" {
+			return m.Node(e.Source)
+		}
+	}
+	return ModelNode{}, false
+}
+
+func (m *Model) drop(gone map[string]bool) {
+	nodes := m.Nodes[:0]
+	for _, n := range m.Nodes {
+		if !gone[n.ID] {
+			nodes = append(nodes, n)
+		}
+	}
+	m.Nodes = nodes
+
+	edges := m.Edges[:0]
+	for _, e := range m.Edges {
+		if !gone[e.Source] && !gone[e.Target] {
+			edges = append(edges, e)
+		}
+	}
+	m.Edges = edges
+}
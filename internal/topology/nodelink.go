@@ -0,0 +1,150 @@
+package topology
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// JSON_NODELINK is networkx's node-link format:
+//
+//	{"directed": true, "multigraph": false, "graph": {},
+//	 "nodes": [{"id": "n1", "Class": "NetworkNode", ...}],
+//	 "links": [{"source": "n1", "target": "n2", "Class": "has"}]}
+//
+// The "id" member carries the vertex id and "source"/"target" the edge ends.
+// A property with one of those names is written with a leading underscore
+// (see escapeProp), so it cannot clobber them.
+
+type nodeLinkDoc struct {
+	Directed   bool             `json:"directed"`
+	Multigraph bool             `json:"multigraph"`
+	Graph      map[string]any   `json:"graph"`
+	Nodes      []map[string]any `json:"nodes"`
+	Links      []map[string]any `json:"links"`
+	Edges      []map[string]any `json:"edges,omitempty"` // networkx >= 3.4 may emit "edges"
+}
+
+func MarshalNodeLink(m *Model) (string, error) {
+	doc := nodeLinkDoc{
+		Directed: true,
+		Graph:    map[string]any{},
+		Nodes:    make([]map[string]any, 0, len(m.Nodes)),
+		Links:    make([]map[string]any, 0, len(m.Edges)),
+	}
+	for _, n := range m.Nodes {
+		obj := joinProps(n.Props, "id")
+		obj["id"] = n.ID
+		doc.Nodes = append(doc.Nodes, obj)
+	}
+	for _, e := range m.Edges {
+		obj := joinProps(e.Props, "source", "target", "key")
+		obj["source"] = e.Source
+		obj["target"] = e.Target
+		doc.Links = append(doc.Links, obj)
+	}
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func UnmarshalNodeLink(s string) (*Model, error) {
+	var doc nodeLinkDoc
+	dec := json.NewDecoder(bytes.NewReader([]byte(s)))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decode node-link graph: %w", err)
+	}
+
+	m := &Model{}
+	for _, obj := range doc.Nodes {
+		id, props := splitProps(obj, "id")
+		m.Nodes = append(m.Nodes, ModelNode{ID: id["id"], Props: props})
+	}
+	links := doc.Links
+	if len(links) == 0 {
+		links = doc.Edges
+	}
+	for _, obj := range links {
+		ends, props := splitProps(obj, "source", "target", "key")
+		m.Edges = append(m.Edges, ModelEdge{Source: ends["source"], Target: ends["target"], Props: props})
+	}
+	return m, nil
+}
+
+// joinProps copies props into a JSON object that will also hold the
+// reserved members, escaping property names that would collide with them.
+func joinProps(props map[string]string, reserved ...string) map[string]any {
+	obj := make(map[string]any, len(props)+len(reserved))
+	for k, v := range props {
+		obj[escapeProp(k, reserved)] = v
+	}
+	return obj
+}
+
+// splitProps stringifies every member of obj, returning the reserved members
+// separately from the vertex/edge properties, whose names are unescaped.
+func splitProps(obj map[string]any, reserved ...string) (map[string]string, map[string]string) {
+	res := make(map[string]string, len(reserved))
+	props := make(map[string]string, len(obj))
+	for k, v := range obj {
+		props[k] = stringify(v)
+	}
+	for _, r := range reserved {
+		if v, ok := props[r]; ok {
+			res[r] = v
+			delete(props, r)
+		}
+	}
+	for k, v := range props {
+		if u := unescapeProp(k, reserved); u != k {
+			delete(props, k)
+			props[u] = v
+		}
+	}
+	return res, props
+}
+
+// escapeProp prefixes an underscore to a property named like a reserved
+// member, or like one with underscores already prefixed, so unescapeProp
+// can tell them apart.
+func escapeProp(k string, reserved []string) string {
+	for _, r := range reserved {
+		if strings.TrimLeft(k, "_") == r {
+			return "_" + k
+		}
+	}
+	return k
+}
+
+func unescapeProp(k string, reserved []string) string {
+	if !strings.HasPrefix(k, "_") {
+		return k
+	}
+	for _, r := range reserved {
+		if strings.TrimLeft(k, "_") == r {
+			return k[1:]
+		}
+	}
+	return k
+}
+
+func stringify(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case json.Number:
+		return t.String()
+	case bool:
+		return strconv.FormatBool(t)
+	default:
+		b, _ := json.Marshal(t) // decoded JSON always re-encodes
+		return string(b)
+	}
+}
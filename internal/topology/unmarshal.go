@@ -0,0 +1,14 @@
+package topology
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+func Unmarshal(s string) (GraphML, error) {
+	var g GraphML
+	if err := xml.NewDecoder(strings.NewReader(s)).Decode(&g); err != nil {
+		return GraphML{}, err
+	}
+	return g, nil
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"context"
+	"sync"
+)
+
+// KeyedMutex serializes work per key: callers locking the same key queue
+// up, callers with different keys proceed concurrently. The zero value is
+// ready to use.
+type KeyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyedLock
+}
+
+// keyedLock is a one-slot semaphore, so waiting for it can be abandoned.
+type keyedLock struct {
+	sem  chan struct{}
+	refs int
+}
+
+// heldKey marks a context whose caller holds a key of a KeyedMutex.
+type heldKey struct {
+	k   *KeyedMutex
+	key string
+}
+
+// Lock blocks until key is free and returns the function that releases it.
+func (k *KeyedMutex) Lock(key string) (unlock func()) {
+	unlock, _ = k.lock(context.Background(), key)
+	return unlock
+}
+
+// LockContext is Lock for callers that pass the returned context on: a
+// nested LockContext for the same key on that context returns immediately,
+// so a caller holding a key can call code that locks it too. If ctx is done
+// before the key is free, it returns ctx.Err() without the lock.
+func (k *KeyedMutex) LockContext(ctx context.Context, key string) (context.Context, func(), error) {
+	held := heldKey{k: k, key: key}
+	if ctx.Value(held) != nil {
+		return ctx, func() {}, nil
+	}
+	unlock, err := k.lock(ctx, key)
+	if err != nil {
+		return ctx, nil, err
+	}
+	return context.WithValue(ctx, held, true), unlock, nil
+}
+
+func (k *KeyedMutex) lock(ctx context.Context, key string) (func(), error) {
+	k.mu.Lock()
+	if k.locks == nil {
+		k.locks = map[string]*keyedLock{}
+	}
+	l, ok := k.locks[key]
+	if !ok {
+		l = &keyedLock{sem: make(chan struct{}, 1)}
+		k.locks[key] = l
+	}
+	l.refs++
+	k.mu.Unlock()
+
+	release := func() {
+		k.mu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(k.locks, key)
+		}
+		k.mu.Unlock()
+	}
+	select {
+	case l.sem <- struct{}{}:
+		return func() {
+			<-l.sem
+			release()
+		}, nil
+	case <-ctx.Done():
+		release()
+		return nil, ctx.Err()
+	}
+}
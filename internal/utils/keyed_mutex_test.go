@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestKeyedMutexSerializesKey(t *testing.T) {
+	var k KeyedMutex
+	unlock := k.Lock("a")
+
+	got := make(chan string, 2)
+	go func() {
+		defer k.Lock("a")()
+		got <- "second a"
+	}()
+	go func() {
+		defer k.Lock("b")()
+		got <- "b"
+	}()
+
+	if g := <-got; g != "b" {
+		t.Fatalf("first to finish = %q, want b while a is held", g)
+	}
+	select {
+	case g := <-got:
+		t.Fatalf("%q ran while a was held", g)
+	case <-time.After(50 * time.Millisecond):
+	}
+	unlock()
+	if g := <-got; g != "second a" {
+		t.Fatalf("got %q, want second a", g)
+	}
+}
+
+func TestKeyedMutexLockContextCancel(t *testing.T) {
+	var k KeyedMutex
+	unlock := k.Lock("a")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, _, err := k.LockContext(ctx, "a"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("LockContext error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	unlock()
+
+	if n := len(k.locks); n != 0 {
+		t.Fatalf("%d keys left after all waiters gave up or unlocked", n)
+	}
+	_, unlock2, err := k.LockContext(context.Background(), "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlock2()
+}
+
+func TestKeyedMutexLockContextReentrant(t *testing.T) {
+	var k KeyedMutex
+	ctx, unlock, err := k.LockContext(context.Background(), "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unlock()
+
+	done := make(chan error, 1)
+	go func() {
+		_, inner, err := k.LockContext(ctx, "a")
+		if err == nil {
+			inner()
+		}
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("nested LockContext on the holder's context blocked")
+	}
+}
@@ -2,11 +2,46 @@ package utils
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
 const fabricTime = "2006-01-02 15:04:05 -0700"
 
+// ParseLeaseDuration parses a Go duration ("36h", "90m") or a whole number of
+// days ("14d").
+func ParseLeaseDuration(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid lease duration: %s", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid lease duration: %s", s)
+	}
+	return d, nil
+}
+
+// LeaseAfter formats from+d the way NormalizeLease does.
+func LeaseAfter(from time.Time, d time.Duration) string {
+	return from.Add(d).Format(fabricTime)
+}
+
+// ParseLease parses a lease time in RFC3339 or the orchestrator's format.
+func ParseLease(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(fabricTime, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid lease time: %s", s)
+}
+
 func NormalizeLease(input string) (string, error) {
 	if input == "" {
 		return "", nil